@@ -0,0 +1,116 @@
+package tesla
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord records a command sent to a vehicle. Secrets in the payload are redacted
+type AuditRecord struct {
+	Time      time.Time       `json:"time"`
+	Account   string          `json:"account,omitempty"`
+	VIN       string          `json:"vin"`
+	VehicleID int64           `json:"vehicle_id"`
+	Command   string          `json:"command"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Result    bool            `json:"result"`
+	Reason    string          `json:"reason,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Latency   time.Duration   `json:"latency_ns"`
+}
+
+// AuditSink receives a record of every command sent to a vehicle. Set it as the client's Audit
+// to enable auditing. Audit is called synchronously on the command path, so it should be quick
+type AuditSink interface {
+	Audit(record *AuditRecord)
+}
+
+// AuditFunc is a function that receives audit records
+type AuditFunc func(record *AuditRecord)
+
+// Audit calls the function with the record
+func (f AuditFunc) Audit(record *AuditRecord) {
+	f(record)
+}
+
+// JSONLinesAuditSink appends audit records to a file, one JSON object per line
+type JSONLinesAuditSink struct {
+	file *os.File
+	mu   sync.Mutex
+	err  error
+}
+
+// NewJSONLinesAuditSink opens the file at path for appending audit records, creating it if required
+func NewJSONLinesAuditSink(path string) (*JSONLinesAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLinesAuditSink{file: file}, nil
+}
+
+// Audit appends the record to the file. Write errors are kept and returned by Err, so that a
+// failure to audit doesn't mask the outcome of the command
+func (s *JSONLinesAuditSink) Audit(record *AuditRecord) {
+	line, err := json.Marshal(record)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		_, err = s.file.Write(append(line, '\n'))
+	}
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+// Err returns the first error encountered writing audit records
+func (s *JSONLinesAuditSink) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the file
+func (s *JSONLinesAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// audit sends a record of a command to the audit sink of the vehicle's client, if it has one
+func (v Vehicle) audit(command string, payload []byte, start time.Time, response *CommandResponse, err error) {
+	client := v.apiClient()
+	if client == nil || client.Audit == nil {
+		return
+	}
+	record := &AuditRecord{
+		Time:      start.UTC(),
+		VIN:       v.Vin,
+		VehicleID: v.ID,
+		Command:   command,
+		Latency:   time.Since(start),
+	}
+	if client.Auth != nil {
+		record.Account = client.Auth.Email
+	}
+	if len(payload) > 0 && json.Valid(payload) {
+		record.Payload = RedactJSON(payload)
+	}
+	if err != nil {
+		record.Error = Redact(err.Error())
+	}
+	if response != nil {
+		record.Result = response.Response.Result
+		record.Reason = response.Response.Reason
+	}
+	client.Audit.Audit(record)
+}
+
+// commandName returns the command, or other vehicle endpoint, a URL refers to
+func commandName(url string) string {
+	url = strings.SplitN(url, "?", 2)[0]
+	return url[strings.LastIndex(url, "/")+1:]
+}
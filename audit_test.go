@@ -0,0 +1,113 @@
+package tesla
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAuditSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	vehicles, _ := client.Vehicles()
+	vehicle := vehicles[0]
+
+	var mu sync.Mutex
+	records := []*AuditRecord{}
+	client.Audit = AuditFunc(func(record *AuditRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record)
+	})
+	lastRecord := func() *AuditRecord {
+		mu.Lock()
+		defer mu.Unlock()
+		return records[len(records)-1]
+	}
+
+	Convey("Should audit commands sent to the vehicle", t, func() {
+		err := vehicle.LockDoors()
+		So(err, ShouldBeNil)
+		record := lastRecord()
+		So(record.Command, ShouldEqual, "door_lock")
+		So(record.VIN, ShouldEqual, "abc123")
+		So(record.VehicleID, ShouldEqual, 1234)
+		So(record.Account, ShouldEqual, "elon@tesla.com")
+		So(record.Result, ShouldBeTrue)
+		So(record.Time.IsZero(), ShouldBeFalse)
+		So(record.Latency, ShouldBeGreaterThan, 0)
+	})
+
+	Convey("Should audit the reason a command was rejected", t, func() {
+		err := vehicle.StartCharging()
+		So(err.Error(), ShouldEqual, "complete")
+		record := lastRecord()
+		So(record.Command, ShouldEqual, "charge_start")
+		So(record.Result, ShouldBeFalse)
+		So(record.Reason, ShouldEqual, "complete")
+	})
+
+	Convey("Should audit commands posted without checking the result", t, func() {
+		err := vehicle.SetChargeLimit(50)
+		So(err, ShouldBeNil)
+		record := lastRecord()
+		So(record.Command, ShouldEqual, "set_charge_limit")
+		So(string(record.Payload), ShouldEqual, `{"percent":50}`)
+	})
+
+	Convey("Should redact secrets from the audited payload", t, func() {
+		err := vehicle.EnableValetMode("1234")
+		So(err, ShouldBeNil)
+		So(string(lastRecord().Payload), ShouldEqual, `{"on":true,"password":"REDACTED"}`)
+	})
+
+	Convey("Should audit failed requests", t, func() {
+		err := Vehicle{ID: 9999, Vin: "xyz789"}.FlashLights()
+		So(err, ShouldNotBeNil)
+		record := lastRecord()
+		So(record.VIN, ShouldEqual, "xyz789")
+		So(record.Error, ShouldEqual, "404 Not Found")
+	})
+
+	Convey("Should audit arbitrary commands", t, func() {
+		_, err := vehicle.Command(context.Background(), "remote_auto_steering_wheel_heat_climate_request", nil)
+		So(err, ShouldNotBeNil)
+		record := lastRecord()
+		So(record.Command, ShouldEqual, "remote_auto_steering_wheel_heat_climate_request")
+		So(record.Reason, ShouldEqual, "not_supported")
+	})
+
+	Convey("Should append audit records to a JSON Lines file", t, func() {
+		dir, err := ioutil.TempDir("", "audit")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		sink, err := NewJSONLinesAuditSink(filepath.Join(dir, "audit.jsonl"))
+		So(err, ShouldBeNil)
+		client.Audit = sink
+		So(vehicle.FlashLights(), ShouldBeNil)
+		So(vehicle.HonkHorn(), ShouldBeNil)
+		So(sink.Close(), ShouldBeNil)
+		So(sink.Err(), ShouldBeNil)
+
+		file, err := os.Open(filepath.Join(dir, "audit.jsonl"))
+		So(err, ShouldBeNil)
+		defer file.Close()
+		commands := []string{}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := &AuditRecord{}
+			So(json.Unmarshal(scanner.Bytes(), record), ShouldBeNil)
+			commands = append(commands, record.Command)
+		}
+		So(commands, ShouldResemble, []string{"flash_lights", "honk_horn"})
+	})
+
+	client.Audit = nil
+}
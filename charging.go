@@ -0,0 +1,138 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrChargingAmpsOutOfRange is returned when the requested charging current exceeds what the vehicle allows
+	ErrChargingAmpsOutOfRange = errors.New("charging amps out of range")
+	// ErrChargeLimitOutOfRange is returned when a target charge level is outside of the vehicle's allowed limits
+	ErrChargeLimitOutOfRange = errors.New("charge limit out of range")
+	// ErrUnknownChargeRate is returned when a charge duration can't be estimated from the charge state
+	ErrUnknownChargeRate = errors.New("unable to determine charge rate")
+)
+
+// SetChargingAmps sets the charging current, validated against the vehicle's maximum allowed current
+func (v Vehicle) SetChargingAmps(amps int) error {
+	chargeState, err := v.ChargeState()
+	if err != nil {
+		return err
+	}
+	if amps < 1 || (chargeState.ChargeCurrentRequestMax > 0 && amps > chargeState.ChargeCurrentRequestMax) {
+		return ErrChargingAmpsOutOfRange
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_charging_amps"
+	body, _ := json.Marshal(chargingAmpsPayload{amps})
+	_, err = v.sendCommand(apiURL, body)
+	return err
+}
+
+// ChargePlanner estimates how long a charge session will take. BatteryCapacity (kWh) is used to
+// convert charger power into a charge rate, and DefaultPower (kW) is assumed when the vehicle is
+// not currently charging. Margin is added to every estimate to allow for charge taper
+type ChargePlanner struct {
+	BatteryCapacity float64
+	DefaultPower    float64
+	Margin          time.Duration
+}
+
+// DefaultChargePlanner assumes a 75 kWh pack on an 11 kW home charger
+var DefaultChargePlanner = &ChargePlanner{
+	BatteryCapacity: 75,
+	DefaultPower:    11,
+	Margin:          15 * time.Minute,
+}
+
+// ChargePlan describes when charging must start to reach a target level by a deadline
+type ChargePlan struct {
+	TargetPercent int
+	Deadline      time.Time
+	Duration      time.Duration
+	StartBy       time.Time
+	StartNow      bool
+}
+
+// PercentPerHour estimates the battery percentage added per hour of charging, preferring the
+// vehicle's reported charge rate, then the charger's power, then the planner's default power
+func (p ChargePlanner) PercentPerHour(state *ChargeState) (float64, error) {
+	if state.ChargeRate > 0 && state.BatteryLevel > 0 && state.BatteryRange > 0 {
+		rangePerPercent := state.BatteryRange / float64(state.BatteryLevel)
+		return state.ChargeRate / rangePerPercent, nil
+	}
+	if p.BatteryCapacity <= 0 {
+		return 0, ErrUnknownChargeRate
+	}
+	if state.ChargerPower > 0 {
+		return float64(state.ChargerPower) / p.BatteryCapacity * 100, nil
+	}
+	if p.DefaultPower > 0 {
+		return p.DefaultPower / p.BatteryCapacity * 100, nil
+	}
+	return 0, ErrUnknownChargeRate
+}
+
+// Plan computes the charge plan to reach the target percent by the deadline, as of now
+func (p ChargePlanner) Plan(state *ChargeState, percent int, deadline, now time.Time) (*ChargePlan, error) {
+	plan := &ChargePlan{
+		TargetPercent: percent,
+		Deadline:      deadline,
+		StartBy:       deadline,
+	}
+	if state.BatteryLevel >= percent {
+		return plan, nil
+	}
+	rate, err := p.PercentPerHour(state)
+	if err != nil {
+		return nil, err
+	}
+	hours := float64(percent-state.BatteryLevel) / rate
+	plan.Duration = time.Duration(hours*float64(time.Hour)) + p.Margin
+	plan.StartBy = deadline.Add(-plan.Duration)
+	plan.StartNow = !now.Before(plan.StartBy)
+	return plan, nil
+}
+
+// ChargeBy works towards charging the vehicle to the supplied percent by the deadline. It sets the
+// charge limit, then starts charging if the deadline can only be met by starting now, or stops
+// charging if it can wait. It is intended to be called periodically until the deadline passes.
+// A nil planner uses DefaultChargePlanner
+func (v Vehicle) ChargeBy(percent int, deadline time.Time, planner *ChargePlanner) (*ChargePlan, error) {
+	if planner == nil {
+		planner = DefaultChargePlanner
+	}
+	chargeState, err := v.ChargeState()
+	if err != nil {
+		return nil, err
+	}
+	if percent < 1 || percent > 100 ||
+		(chargeState.ChargeLimitSocMin > 0 && percent < chargeState.ChargeLimitSocMin) ||
+		(chargeState.ChargeLimitSocMax > 0 && percent > chargeState.ChargeLimitSocMax) {
+		return nil, ErrChargeLimitOutOfRange
+	}
+	if chargeState.ChargeLimitSoc != percent {
+		err = v.SetChargeLimit(percent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plan, err := planner.Plan(chargeState, percent, deadline, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// A vehicle that is starting to charge is treated as charging, so it isn't told to start again
+	charging := chargeState.ChargingState == ChargingCharging || chargeState.ChargingState == ChargingStarting
+	switch {
+	case plan.StartNow && !charging:
+		err = v.StartCharging()
+	case !plan.StartNow && charging:
+		err = v.StopCharging()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
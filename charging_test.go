@@ -0,0 +1,122 @@
+package tesla
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChargePlannerSpec(t *testing.T) {
+	now := time.Date(2021, 4, 18, 22, 0, 0, 0, time.UTC)
+	deadline := time.Date(2021, 4, 19, 7, 0, 0, 0, time.UTC)
+	planner := ChargePlanner{BatteryCapacity: 100, DefaultPower: 10}
+
+	Convey("Should estimate the charge rate from the reported range rate", t, func() {
+		state := &ChargeState{BatteryLevel: 50, BatteryRange: 150, ChargeRate: 30}
+		rate, err := planner.PercentPerHour(state)
+		So(err, ShouldBeNil)
+		So(rate, ShouldEqual, 10)
+	})
+
+	Convey("Should estimate the charge rate from the charger power", t, func() {
+		state := &ChargeState{BatteryLevel: 50, ChargerPower: 5}
+		rate, err := planner.PercentPerHour(state)
+		So(err, ShouldBeNil)
+		So(rate, ShouldEqual, 5)
+	})
+
+	Convey("Should fail to estimate the charge rate without a capacity", t, func() {
+		_, err := ChargePlanner{}.PercentPerHour(&ChargeState{BatteryLevel: 50})
+		So(err, ShouldEqual, ErrUnknownChargeRate)
+	})
+
+	Convey("Should wait to charge when there is time to spare", t, func() {
+		state := &ChargeState{BatteryLevel: 50}
+		plan, err := planner.Plan(state, 80, deadline, now)
+		So(err, ShouldBeNil)
+		So(plan.Duration, ShouldEqual, 3*time.Hour)
+		So(plan.StartBy, ShouldResemble, deadline.Add(-3*time.Hour))
+		So(plan.StartNow, ShouldBeFalse)
+	})
+
+	Convey("Should start charging when the deadline is near", t, func() {
+		state := &ChargeState{BatteryLevel: 10}
+		plan, err := planner.Plan(state, 100, deadline, now)
+		So(err, ShouldBeNil)
+		So(plan.StartNow, ShouldBeTrue)
+	})
+
+	Convey("Should not charge when already at the target", t, func() {
+		state := &ChargeState{BatteryLevel: 90}
+		plan, err := planner.Plan(state, 80, deadline, now)
+		So(err, ShouldBeNil)
+		So(plan.Duration, ShouldEqual, 0)
+		So(plan.StartNow, ShouldBeFalse)
+	})
+}
+
+func TestChargingSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should set the charging amps", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.SetChargingAmps(32)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should reject charging amps above the vehicle maximum", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.SetChargingAmps(48)
+		So(err, ShouldEqual, ErrChargingAmpsOutOfRange)
+		err = vehicle.SetChargingAmps(0)
+		So(err, ShouldEqual, ErrChargingAmpsOutOfRange)
+	})
+
+	Convey("Should leave a fully charged car alone", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		plan, err := vehicle.ChargeBy(90, time.Now().Add(time.Hour), nil)
+		So(err, ShouldBeNil)
+		So(plan.StartNow, ShouldBeFalse)
+	})
+
+	Convey("Should reject a target outside of the charge limits", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		_, err = vehicle.ChargeBy(20, time.Now().Add(time.Hour), nil)
+		So(err, ShouldEqual, ErrChargeLimitOutOfRange)
+	})
+	Convey("Should charge by a deadline when the vehicle doesn't report its charge limits", t, func() {
+		_, client := serveAPI(t)
+		commands := []string{}
+		client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/data_request/charge_state") {
+				body := `{"response":{"charging_state":"Starting","battery_level":60,"charge_limit_soc":80}}`
+				return &http.Response{StatusCode: 200, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+			}
+			if i := strings.Index(req.URL.Path, "/command/"); i >= 0 {
+				commands = append(commands, req.URL.Path[i+len("/command/"):])
+			}
+			return next(req)
+		})
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		plan, err := vehicles[0].ChargeBy(80, time.Now().Add(time.Hour), nil)
+		So(err, ShouldBeNil)
+		So(plan.StartNow, ShouldBeTrue)
+		// The vehicle is already starting to charge, so it isn't told to start again
+		So(commands, ShouldBeEmpty)
+		_, err = vehicles[0].ChargeBy(101, time.Now().Add(time.Hour), nil)
+		So(err, ShouldEqual, ErrChargeLimitOutOfRange)
+	})
+}
@@ -2,6 +2,7 @@ package tesla
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -32,9 +33,12 @@ type Token struct {
 
 // Client provides the client and associated elements for interacting with the Tesla API
 type Client struct {
-	Auth  *Auth
-	Token *Token
-	HTTP  *http.Client
+	Auth         *Auth
+	Token        *Token
+	HTTP         *http.Client
+	Audit        AuditSink
+	Interceptors []Interceptor
+	Telemetry    *Telemetry
 }
 
 var (
@@ -122,13 +126,23 @@ func (c Client) delete(url string) error {
 
 // Calls an HTTP GET
 func (c Client) get(url string) ([]byte, error) {
-	req, _ := http.NewRequest("GET", url, nil)
+	return c.getContext(context.Background(), url)
+}
+
+// Calls an HTTP GET, as part of the supplied context
+func (c Client) getContext(ctx context.Context, url string) ([]byte, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 	return c.processRequest(req)
 }
 
 // Calls an HTTP POST with a JSON body
 func (c Client) post(url string, body []byte) ([]byte, error) {
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	return c.postContext(context.Background(), url, body)
+}
+
+// Calls an HTTP POST with a JSON body, as part of the supplied context
+func (c Client) postContext(ctx context.Context, url string, body []byte) ([]byte, error) {
+	req, _ := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	return c.processRequest(req)
 }
 
@@ -138,15 +152,26 @@ func (c Client) put(resource string, body []byte) ([]byte, error) {
 	return c.processRequest(req)
 }
 
+// StatusError is returned when the API responds with a status other than 200 OK. Its message is
+// the response's status, such as "404 Not Found"
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return e.Status
+}
+
 // Processes a HTTP POST/PUT request
 func (c Client) processRequest(req *http.Request) ([]byte, error) {
 	c.setHeaders(req)
-	res, err := c.HTTP.Do(req)
+	res, err := c.roundTrip(req)
 	if err != nil {
-		return nil, err
+		return nil, redactError(err)
 	}
 	if res.StatusCode != 200 {
-		return nil, errors.New(res.Status)
+		return nil, &StatusError{StatusCode: res.StatusCode, Status: res.Status}
 	}
 	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
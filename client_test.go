@@ -3,11 +3,14 @@ package tesla
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClientSpec(t *testing.T) {
@@ -108,6 +111,34 @@ func TestClientWithTokenSpec(t *testing.T) {
 	BaseURL = previousURL
 }
 
+// serveAPI serves the mock API, points the package at it and returns a client logged in to it.
+// The server is closed and the package's URLs are restored when the test ends
+func serveAPI(t *testing.T) (*httptest.Server, *Client) {
+	ts := serveHTTP(t)
+	previousAuthURL := AuthURL
+	previousURL := BaseURL
+	AuthURL = ts.URL + "/oauth/token"
+	BaseURL = ts.URL + "/api/1"
+	t.Cleanup(func() {
+		AuthURL = previousAuthURL
+		BaseURL = previousURL
+		ts.Close()
+	})
+
+	auth := &Auth{
+		GrantType:    "password",
+		ClientID:     "abc123",
+		ClientSecret: "def456",
+		Email:        "elon@tesla.com",
+		Password:     "go",
+	}
+	client, err := NewClient(auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts, client
+}
+
 func serveHTTP(t *testing.T) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		body, _ := ioutil.ReadAll(req.Body)
@@ -131,6 +162,14 @@ func serveHTTP(t *testing.T) *httptest.Server {
 			checkHeaders(t, req)
 			w.WriteHeader(200)
 			w.Write([]byte(VehiclesJSON))
+		case "/api/1/vehicles/1234":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(VehicleJSON))
+		case "/api/1/vehicles/8642":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(`{"response":null}`))
 		case "/api/1/vehicles/1234/mobile_enabled":
 			checkHeaders(t, req)
 			w.WriteHeader(200)
@@ -150,18 +189,52 @@ func serveHTTP(t *testing.T) *httptest.Server {
 			checkHeaders(t, req)
 			w.WriteHeader(200)
 			w.Write([]byte(GuiSettingsJSON))
+		case "/api/1/vehicles/1234/data_request/vehicle_config":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(VehicleConfigJSON))
 		case "/api/1/vehicles/1234/data_request/vehicle_state":
 			checkHeaders(t, req)
 			w.WriteHeader(200)
 			w.Write([]byte(VehicleStateJSON))
+		case "/api/1/vehicles/3579/data_request/vehicle_state":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(MediaDisabledVehicleStateJSON))
+		case "/api/1/vehicles/3579/data_request/vehicle_config":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(NoNavigationVehicleConfigJSON))
+		case "/api/1/vehicles/4680/data_request/vehicle_state":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(ValetPinNeededVehicleStateJSON))
+		case "/api/1/vehicles/1234/vehicle_data",
+			"/api/1/vehicles/1234/vehicle_data?let_sleep=true":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(VehicleDataJSON))
+		case "/api/1/vehicles/1234/vehicle_data?endpoints=charge_state%3Blocation_data&let_sleep=true":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(LocationDataJSON))
 		case "/api/1/vehicles/1234/wake_up":
 			checkHeaders(t, req)
 			w.WriteHeader(200)
 			w.Write([]byte(WakeupResponseJSON))
+		case "/api/1/vehicles/2468/wake_up":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(strings.Replace(WakeupResponseJSON, `"state":"online"`, `"state":"asleep"`, 1)))
+		case "/api/1/vehicles/1357/wake_up":
+			checkHeaders(t, req)
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(200)
+			w.Write([]byte(strings.Replace(WakeupResponseJSON, `"state":"online"`, `"state":"asleep"`, 1)))
 		case "/api/1/vehicles/1234/command/set_charge_limit":
 			w.WriteHeader(200)
 			Convey("Should receive a set charge limit request", t, func() {
-				So(string(body), ShouldEqual, `{"percent": 50}`)
+				So(string(body), ShouldEqual, `{"percent":50}`)
 			})
 		case "/api/1/vehicles/1234/command/charge_standard":
 			checkHeaders(t, req)
@@ -174,6 +247,7 @@ func serveHTTP(t *testing.T) *httptest.Server {
 		case "/api/1/vehicles/1234/command/charge_stop",
 			"/api/1/vehicles/1234/command/charge_max_range",
 			"/api/1/vehicles/1234/command/charge_port_door_open",
+			"/api/1/vehicles/1234/command/charge_port_door_close",
 			"/api/1/vehicles/1234/command/flash_lights",
 			"/api/1/vehicles/1234/command/honk_horn",
 			"/api/1/vehicles/1234/command/auto_conditioning_start",
@@ -181,8 +255,21 @@ func serveHTTP(t *testing.T) *httptest.Server {
 			"/api/1/vehicles/1234/command/door_unlock",
 			"/api/1/vehicles/1234/command/door_lock",
 			"/api/1/vehicles/1234/command/reset_valet_pin",
-			"/api/1/vehicles/1234/command/set_temps?driver_temp=72&passenger_temp=72",
-			"/api/1/vehicles/1234/command/remote_start_drive?password=foo":
+			"/api/1/vehicles/1234/command/set_sentry_mode",
+			"/api/1/vehicles/1234/command/media_toggle_playback",
+			"/api/1/vehicles/1234/command/media_next_track",
+			"/api/1/vehicles/1234/command/media_prev_track",
+			"/api/1/vehicles/1234/command/media_next_fav",
+			"/api/1/vehicles/1234/command/media_prev_fav",
+			"/api/1/vehicles/1234/command/media_volume_up",
+			"/api/1/vehicles/1234/command/media_volume_down",
+			"/api/1/vehicles/1234/command/set_temps",
+			"/api/1/vehicles/1234/command/trunk_open",
+			"/api/1/vehicles/1234/command/remote_seat_heater_request",
+			"/api/1/vehicles/1234/command/remote_steering_wheel_heater_request",
+			"/api/1/vehicles/1234/command/remote_start_drive",
+			"/api/1/vehicles/1234/command/schedule_software_update",
+			"/api/1/vehicles/1234/command/cancel_software_update":
 			checkHeaders(t, req)
 			w.WriteHeader(200)
 			w.Write([]byte(CommandResponseJSON))
@@ -204,6 +291,31 @@ func serveHTTP(t *testing.T) *httptest.Server {
 				So(autoParkRequest.Lat, ShouldEqual, 35.1)
 				So(autoParkRequest.Lon, ShouldEqual, 20.2)
 			})
+		case "/api/1/vehicles/5678/data_request/drive_state":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			now := time.Now()
+			w.Write([]byte(fmt.Sprintf(ParkedDriveStateJSON, now.Unix(), now.UnixNano()/int64(time.Millisecond))))
+		case "/api/1/vehicles/5678/command/autopark_request":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Auto park request should have appropriate body", t, func() {
+				autoParkRequest := &AutoParkRequest{}
+				err := json.Unmarshal(body, autoParkRequest)
+				So(err, ShouldBeNil)
+				So(autoParkRequest.Action, shouldBeValidAutoparkCommand)
+				So(autoParkRequest.VehicleID, ShouldEqual, 456)
+				So(autoParkRequest.Lat, ShouldEqual, 37.4)
+				So(autoParkRequest.Lon, ShouldEqual, -122.1)
+			})
+		case "/api/1/vehicles/5678/command/remote_start_drive":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive the password in the request body", t, func() {
+				So(string(body), ShouldEqual, `{"password":"foo"}`)
+			})
 		case "/api/1/vehicles/1234/command/trigger_homelink":
 			w.WriteHeader(200)
 			Convey("Auto park request should have appropriate body", t, func() {
@@ -213,26 +325,130 @@ func serveHTTP(t *testing.T) *httptest.Server {
 				So(autoParkRequest.Lat, ShouldEqual, 35.1)
 				So(autoParkRequest.Lon, ShouldEqual, 20.2)
 			})
+		case "/api/1/vehicles/1234/command/set_scheduled_charging":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a scheduled charging request", t, func() {
+				So(string(body), ShouldEqual, `{"enable":true,"time":450}`)
+			})
+		case "/api/1/vehicles/1234/command/set_scheduled_departure":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a scheduled departure request", t, func() {
+				So(string(body), ShouldEqual, `{"enable":true,"departure_time":480,"preconditioning_enabled":true,"preconditioning_weekdays_only":true,"off_peak_charging_enabled":true,"off_peak_charging_weekdays_only":false,"end_off_peak_time":360}`)
+			})
+		case "/api/1/vehicles/1234/command/set_charging_amps":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a set charging amps request", t, func() {
+				So(string(body), ShouldEqual, `{"charging_amps":32}`)
+			})
+		case "/api/1/vehicles/1234/command/adjust_volume":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive an adjust volume request", t, func() {
+				So(string(body), ShouldEqual, `{"volume":5.5}`)
+			})
+		case "/api/1/vehicles/1234/command/navigation_request":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a navigation share request", t, func() {
+				shareRequest := map[string]interface{}{}
+				err := json.Unmarshal(body, &shareRequest)
+				So(err, ShouldBeNil)
+				So(shareRequest["type"], ShouldEqual, "share_ext_content_raw")
+				So(shareRequest["locale"], ShouldEqual, "en-US")
+				So(shareRequest["timestamp_ms"], ShouldNotBeEmpty)
+				So(shareRequest["value"], ShouldResemble, map[string]interface{}{"android.intent.extra.TEXT": "3500 Deer Creek Road, Palo Alto, CA"})
+			})
+		case "/api/1/vehicles/1234/command/navigation_gps_request":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a navigation GPS request", t, func() {
+				So(string(body), ShouldEqual, `{"lat":37.4,"lon":-122.1,"order":0}`)
+			})
+		case "/api/1/vehicles/1234/command/navigation_sc_request":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a navigation Supercharger request", t, func() {
+				So(string(body), ShouldEqual, `{"id":42,"order":0}`)
+			})
+		case "/api/1/vehicles/1234/command/speed_limit_activate",
+			"/api/1/vehicles/1234/command/speed_limit_deactivate",
+			"/api/1/vehicles/1234/command/speed_limit_clear_pin":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a speed limit PIN request", t, func() {
+				So(string(body), ShouldEqual, `{"pin":"1234"}`)
+			})
+		case "/api/1/vehicles/1234/command/speed_limit_set_limit":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a set speed limit request", t, func() {
+				So(string(body), ShouldEqual, `{"limit_mph":65}`)
+			})
+		case "/api/1/vehicles/1234/command/set_valet_mode":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a valet mode request", t, func() {
+				So(string(body), ShouldBeIn, []string{`{"on":true,"password":"1234"}`, `{"on":true}`, `{"on":false}`})
+			})
+		case "/api/1/vehicles/1234/command/window_control":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive a window control request near the car", t, func() {
+				So(string(body), ShouldBeIn, []string{`{"command":"vent","lat":35.1,"lon":20.2}`, `{"command":"close","lat":35.1,"lon":20.2}`})
+			})
+		case "/api/1/vehicles/1234/command/remote_boombox":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(CommandResponseJSON))
+			Convey("Should receive the raw command payload", t, func() {
+				So(string(body), ShouldEqual, `{"sound":2000}`)
+			})
+		case "/api/1/vehicles/1234/command/remote_auto_steering_wheel_heat_climate_request":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(`{"response":{"reason":"not_supported","result":false}}`))
+		case "/api/1/vehicles/1234/release_notes":
+			checkHeaders(t, req)
+			w.WriteHeader(200)
+			w.Write([]byte(`{"response":{"release_notes":[{"title":"Boombox"}]}}`))
+		case "/api/1/vehicles/1234/missing":
+			w.WriteHeader(404)
 		case "/api/1/vehicles/1234/command/sun_roof_control":
 			w.WriteHeader(200)
 			Convey("Should set the Pano roof appropriately", t, func() {
 				passed := false
 				strBody := string(body)
-				if strBody == `{"state": "vent", "percent":0}` {
+				if strBody == `{"state":"vent","percent":0}` {
 					passed = true
 				}
-				if strBody == `{"state": "open", "percent":0}` {
+				if strBody == `{"state":"open","percent":0}` {
 					passed = true
 				}
-				if strBody == `{"state": "move", "percent":50}` {
+				if strBody == `{"state":"move","percent":50}` {
 					passed = true
 				}
-				if strBody == `{"state": "close", "percent":0}` {
+				if strBody == `{"state":"close","percent":0}` {
 					passed = true
 				}
 				So(passed, ShouldBeTrue)
 
 			})
+		default:
+			w.WriteHeader(404)
 		}
 	}))
 }
@@ -0,0 +1,113 @@
+// Command tesla sends commands from the command registry to a vehicle. Credentials are read
+// from the same environment variables as the examples, and TESLA_VIN selects the vehicle when
+// the account has more than one.
+//
+//	tesla list
+//	tesla <command> [json payload]
+//
+// Commands that need a capability are refused, as are commands sharing an endpoint with them,
+// since a raw payload would bypass the checks their Vehicle methods make: moving the vehicle
+// needs a motion capability and heartbeats, and media and navigation need vehicle support
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rdbell/tesla"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: tesla list | tesla <command> [json payload]")
+		os.Exit(2)
+	}
+	if os.Args[1] == "list" {
+		list()
+		return
+	}
+	err := run(os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// list prints every command in the registry
+func list() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, command := range tesla.CommandRegistry {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", command.Method, command.Endpoint, command.Description)
+	}
+	w.Flush()
+}
+
+// run sends a command to the vehicle, with an optional raw JSON payload
+func run(name string, args []string) error {
+	command, ok := tesla.LookupCommand(name)
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if restricted(command) {
+		return fmt.Errorf("%s needs checks the command line can't make; call Vehicle.%s instead", command.Method, command.Method)
+	}
+	var payload interface{}
+	if len(args) > 0 {
+		if !json.Valid([]byte(args[0])) {
+			return fmt.Errorf("payload is not valid JSON")
+		}
+		payload = []byte(args[0])
+	} else if command.Payload != nil {
+		example, _ := json.Marshal(command.Payload)
+		return fmt.Errorf("%s requires a payload, e.g. %s", command.Method, example)
+	}
+	client, err := tesla.NewClient(
+		&tesla.Auth{
+			ClientID:     os.Getenv("TESLA_CLIENT_ID"),
+			ClientSecret: os.Getenv("TESLA_CLIENT_SECRET"),
+			Email:        os.Getenv("TESLA_USERNAME"),
+			Password:     os.Getenv("TESLA_PASSWORD"),
+		})
+	if err != nil {
+		return err
+	}
+	vehicle, err := findVehicle(client, os.Getenv("TESLA_VIN"))
+	if err != nil {
+		return err
+	}
+	response, err := vehicle.Command(context.Background(), command.Endpoint, payload)
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.Response.Result)
+	return nil
+}
+
+// restricted reports whether the command, or another command sent to the same endpoint, needs a
+// capability or moves the vehicle
+func restricted(command tesla.CommandSpec) bool {
+	for _, other := range tesla.CommandRegistry {
+		if other.Endpoint == command.Endpoint && (other.MovesVehicle || len(other.Capabilities) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// findVehicle returns the vehicle with the supplied VIN, or the first vehicle if vin is empty
+func findVehicle(client *tesla.Client, vin string) (*tesla.Vehicle, error) {
+	if vin != "" {
+		return client.VehicleByVIN(vin)
+	}
+	vehicles, err := client.Vehicles()
+	if err != nil {
+		return nil, err
+	}
+	if len(vehicles) == 0 {
+		return nil, tesla.ErrVehicleNotFound
+	}
+	return vehicles[0], nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rdbell/tesla"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRunSpec(t *testing.T) {
+	Convey("Should refuse commands that need a capability", t, func() {
+		for _, name := range []string{"AutoparkForward", "Start", "MediaNextTrack", "NavigateToLocation"} {
+			err := run(name, []string{`{}`})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "can't make")
+		}
+	})
+
+	Convey("Should refuse commands sharing an endpoint with a restricted command", t, func() {
+		command, _ := tesla.LookupCommand("AutoparkAbort")
+		So(restricted(command), ShouldBeTrue)
+		err := run("AutoparkAbort", []string{`{"action":"start_forward"}`})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Should allow commands without capabilities", t, func() {
+		command, _ := tesla.LookupCommand("set_charge_limit")
+		So(restricted(command), ShouldBeFalse)
+		command, _ = tesla.LookupCommand("CloseWindows")
+		So(restricted(command), ShouldBeFalse)
+	})
+
+	Convey("Should ask for a payload with a placeholder example", t, func() {
+		err := run("SetChargeLimit", nil)
+		So(err.Error(), ShouldEqual, `SetChargeLimit requires a payload, e.g. {"percent":80}`)
+	})
+}
@@ -1,9 +1,11 @@
 package tesla
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
+	"time"
 )
 
 // CommandResponse represents a response from the Tesla API after POSTing a command
@@ -22,25 +24,33 @@ type AutoParkRequest struct {
 	Action    string  `json:"action,omitempty"`
 }
 
-// AutoparkAbort aborts an autopark request
+// AutoparkAbort aborts an autopark request. The abort is always sent, even if the
+// vehicle's location can't be fetched
 func (v Vehicle) AutoparkAbort() error {
-	return v.autoPark("abort")
+	driveState, err := v.DriveState()
+	if err != nil {
+		driveState = &DriveState{}
+	}
+	return v.autoPark("abort", driveState)
 }
 
-// AutoparkForward commands the vehicle to pull forward
-func (v Vehicle) AutoparkForward() error {
-	return v.autoPark("start_forward")
+// AutoparkForward commands the vehicle to pull forward. The vehicle must be in park and the
+// returned maneuver must be kept alive with heartbeats, as described by the safety policy.
+// A nil policy uses DefaultSafetyPolicy
+func (v Vehicle) AutoparkForward(ctx context.Context, capability *MotionCapability, policy *SafetyPolicy) (*Maneuver, error) {
+	return v.startManeuver(ctx, capability, policy, "start_forward")
 }
 
-// AutoparkReverse commands the vehicle to go in reverse
-func (v Vehicle) AutoparkReverse() error {
-	return v.autoPark("start_reverse")
+// AutoparkReverse commands the vehicle to go in reverse. The vehicle must be in park and the
+// returned maneuver must be kept alive with heartbeats, as described by the safety policy.
+// A nil policy uses DefaultSafetyPolicy
+func (v Vehicle) AutoparkReverse(ctx context.Context, capability *MotionCapability, policy *SafetyPolicy) (*Maneuver, error) {
+	return v.startManeuver(ctx, capability, policy, "start_reverse")
 }
 
-// autoPark performs the auto park/summon request for the vehicle
-func (v Vehicle) autoPark(action string) error {
+// autoPark performs the auto park/summon request for the vehicle at its current location
+func (v Vehicle) autoPark(action string, driveState *DriveState) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/autopark_request"
-	driveState, _ := v.DriveState()
 	autoParkRequest := &AutoParkRequest{
 		VehicleID: v.VehicleID,
 		Lat:       driveState.Latitude,
@@ -49,7 +59,7 @@ func (v Vehicle) autoPark(action string) error {
 	}
 	body, _ := json.Marshal(autoParkRequest)
 
-	_, err := sendCommand(apiURL, body)
+	_, err := v.sendCommand(apiURL, body)
 	return err
 }
 
@@ -64,7 +74,7 @@ func (v Vehicle) autoPark(action string) error {
 // 	}
 // 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/" + command
 // 	fmt.Println(apiURL)
-// 	_, err := sendCommand(apiURL, nil)
+// 	_, err := v.sendCommand(apiURL, nil)
 // 	return err
 // }
 
@@ -79,14 +89,19 @@ func (v Vehicle) TriggerHomelink() error {
 	}
 	body, _ := json.Marshal(autoParkRequest)
 
-	_, err := sendCommand(apiURL, body)
+	_, err := v.sendCommand(apiURL, body)
 	return err
 }
 
 // Wakeup wakes up the vehicle when it is powered off
 func (v Vehicle) Wakeup() (*Vehicle, error) {
+	return v.wakeup(context.Background())
+}
+
+// wakeup wakes up the vehicle, as part of the supplied context
+func (v Vehicle) wakeup(ctx context.Context) (*Vehicle, error) {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/wake_up"
-	body, err := sendCommand(apiURL, nil)
+	body, err := v.sendCommandContext(ctx, apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -95,117 +110,27 @@ func (v Vehicle) Wakeup() (*Vehicle, error) {
 	if err != nil {
 		return nil, err
 	}
+	vehicleResponse.Response.client = v.apiClient()
 	return vehicleResponse.Response, nil
 }
 
-// OpenChargePort opens the vehicle's charge port
-func (v Vehicle) OpenChargePort() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_port_door_open"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// CloseChargePort closes the vehicle's charge port
-func (v Vehicle) CloseChargePort() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_port_door_close"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// ResetValetPIN resets the valet mode PIN, if set
-func (v Vehicle) ResetValetPIN() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/reset_valet_pin"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// SetChargeLimitStandard sets the charge limit to the default setting
-func (v Vehicle) SetChargeLimitStandard() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_standard"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// SetChargeLimitMax sets the charge limit to the maximum value
-func (v Vehicle) SetChargeLimitMax() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_max_range"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
 // SetChargeLimit sets the charge limit to a supplied percent value
 func (v Vehicle) SetChargeLimit(percent int) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_charge_limit"
-	postJSON := `{"percent": ` + strconv.Itoa(percent) + `}`
-	_, err := ActiveClient.post(apiURL, []byte(postJSON))
-	return err
-}
-
-// StartCharging starts the charging of the vehicle if charging cable is inserted
-func (v Vehicle) StartCharging() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_start"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// StopCharging stops a vehicle's charge session
-func (v Vehicle) StopCharging() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/charge_stop"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// FlashLights flashes the lights of the vehicle
-func (v Vehicle) FlashLights() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/flash_lights"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// HonkHorn honks the vehicle's horn
-func (v *Vehicle) HonkHorn() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/honk_horn"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// UnlockDoors unlocks the vehicle's doors
-func (v Vehicle) UnlockDoors() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/door_unlock"
-	_, err := sendCommand(apiURL, nil)
-	return err
-}
-
-// LockDoors locks the vehicle's doors
-func (v Vehicle) LockDoors() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/door_lock"
-	_, err := sendCommand(apiURL, nil)
+	body, _ := json.Marshal(percentPayload{percent})
+	_, err := v.postCommand(apiURL, body)
 	return err
 }
 
 // SetTemperature sets the temperature of the vehicle
 // Driver and passenger zones are controlled individually
 func (v Vehicle) SetTemperature(driver float64, passenger float64) error {
-	driveTemp := strconv.FormatFloat(driver, 'f', -1, 32)
-	passengerTemp := strconv.FormatFloat(passenger, 'f', -1, 32)
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_temps"
-	postJSON := `{"driver_temp": "` + driveTemp + `", "passenger_temp":` + passengerTemp + `}`
-	_, err := ActiveClient.post(apiURL, []byte(postJSON))
-
-	return err
-}
-
-// StartAirConditioning starts the vehicle's air conditioner
-func (v Vehicle) StartAirConditioning() error {
-	url := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/auto_conditioning_start"
-	_, err := sendCommand(url, nil)
-	return err
-}
+	driverTemp := strconv.FormatFloat(driver, 'f', -1, 32)
+	passengerTemp := strconv.FormatFloat(passenger, 'f', -1, 32)
+	body, _ := json.Marshal(temperaturePayload{driverTemp, json.Number(passengerTemp)})
+	_, err := v.postCommand(apiURL, body)
 
-// StopAirConditioning stops the vehicle's air conditioner
-func (v Vehicle) StopAirConditioning() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/auto_conditioning_stop"
-	_, err := sendCommand(apiURL, nil)
 	return err
 }
 
@@ -213,23 +138,33 @@ func (v Vehicle) StopAirConditioning() error {
 // values for each state are open = 100%, close = 0%, comfort = 80%, vent = %15, move = set %
 func (v Vehicle) MovePanoRoof(state string, percent int) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/sun_roof_control"
-	postJSON := `{"state": "` + state + `", "percent":` + strconv.Itoa(percent) + `}`
-	_, err := ActiveClient.post(apiURL, []byte(postJSON))
+	body, _ := json.Marshal(sunRoofPayload{state, percent})
+	_, err := v.postCommand(apiURL, body)
 	return err
 }
 
-// Start starts the car by turning it on. Requires the Tesla account password
-func (v Vehicle) Start(password string) error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/remote_start_drive?password=" + password
-	_, err := sendCommand(apiURL, nil)
+// Start starts the car by turning it on, allowing it to be driven without a key. Requires
+// a motion capability and the vehicle to be in park. The Tesla account password is sent in the
+// request body; pass an empty password to rely on the client's token alone
+func (v Vehicle) Start(capability *MotionCapability, password string) error {
+	_, err := v.checkParked(capability, DefaultSafetyPolicy)
+	if err != nil {
+		return err
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/remote_start_drive"
+	var body []byte
+	if password != "" {
+		body, _ = json.Marshal(passwordPayload{password})
+	}
+	_, err = v.sendCommand(apiURL, body)
 	return err
 }
 
 // OpenTrunk opens the trunk. Valid trunk values are 'front' and 'rear'
 func (v Vehicle) OpenTrunk(trunk string) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/trunk_open" // ?which_trunk=" + trunk
-	postJSON := `{"which_trunk": "` + trunk + `"}`
-	_, err := ActiveClient.post(apiURL, []byte(postJSON))
+	body, _ := json.Marshal(trunkPayload{trunk})
+	_, err := v.postCommand(apiURL, body)
 	return err
 }
 
@@ -238,32 +173,30 @@ func (v Vehicle) VentWindows() error {
 	return v.windows("vent")
 }
 
-// CloseWindows closes the vehicle's windows (model 3 only?)
+// CloseWindows closes the vehicle's windows
 func (v Vehicle) CloseWindows() error {
 	return v.windows("close")
 }
 
-// windows vents or closes the windows
+// windows vents or closes the windows. The vehicle's own location is sent, as the
+// Tesla API requires the request to come from near the vehicle to close the windows
 func (v Vehicle) windows(action string) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/window_control"
-	windowRequest := struct {
-		VehicleID string `json:"command"`
-		Lat       int    `json:"lat"`
-		Lon       int    `json:"lon"`
-	}{
-		action, 0, 0,
+	driveState, err := v.DriveState()
+	if err != nil {
+		return err
 	}
-	body, _ := json.Marshal(windowRequest)
+	body, _ := json.Marshal(windowPayload{action, driveState.Latitude, driveState.Longitude})
 
-	_, err := sendCommand(apiURL, body)
+	_, err = v.sendCommand(apiURL, body)
 	return err
 }
 
 // SetSentryMode controls Sentry Mode's active state (true/false)
 func (v Vehicle) SetSentryMode(on bool) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_sentry_mode"
-	postJSON := `{"on": "` + strconv.FormatBool(on) + `"}`
-	_, err := sendCommand(apiURL, []byte(postJSON))
+	body, _ := json.Marshal(switchPayload{strconv.FormatBool(on)})
+	_, err := v.sendCommand(apiURL, body)
 	return err
 }
 
@@ -275,14 +208,8 @@ func (v Vehicle) HeatSeat(seat, level int) error {
 		panic(err)
 	}
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/remote_seat_heater_request"
-	seatRequest := struct {
-		Heater int `json:"heater"`
-		Level  int `json:"level"`
-	}{
-		seat, level,
-	}
-	body, _ := json.Marshal(seatRequest)
-	_, err = sendCommand(apiURL, body)
+	body, _ := json.Marshal(seatHeaterPayload{seat, level})
+	_, err = v.sendCommand(apiURL, body)
 	return err
 }
 
@@ -292,8 +219,8 @@ func (v Vehicle) HeatWheel(on bool) error {
 	err := v.StartAirConditioning()
 
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/remote_steering_wheel_heater_request"
-	postJSON := `{"on": "` + strconv.FormatBool(on) + `"}`
-	_, err = sendCommand(apiURL, []byte(postJSON))
+	body, _ := json.Marshal(switchPayload{strconv.FormatBool(on)})
+	_, err = v.sendCommand(apiURL, body)
 	return err
 }
 
@@ -301,21 +228,44 @@ func (v Vehicle) HeatWheel(on bool) error {
 // An update must already be available for this command to work
 func (v Vehicle) ScheduleSoftwareUpdate(offset int64) error {
 	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/schedule_software_update"
-	theJSON := `{"offset_sec": ` + strconv.FormatInt(offset, 10) + `}`
-	_, err := ActiveClient.post(apiURL, []byte(theJSON))
+	body, _ := json.Marshal(softwareUpdatePayload{offset})
+	_, err := v.postCommand(apiURL, body)
 	return err
 }
 
-// CancelSoftwareUpdate cancels a previously-scheduled software update that has not yet started
-func (v Vehicle) CancelSoftwareUpdate() error {
-	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/cancel_software_update"
-	_, err := sendCommand(apiURL, nil)
-	return err
+// postCommand sends a command to the vehicle, recording it in the audit log, without checking the result
+func (v Vehicle) postCommand(url string, reqBody []byte) ([]byte, error) {
+	return v.postCommandContext(context.Background(), url, reqBody)
+}
+
+// postCommandContext sends a command to the vehicle as part of the supplied context, recording it
+// in the audit log and telemetry, without checking the result
+func (v Vehicle) postCommandContext(ctx context.Context, url string, reqBody []byte) ([]byte, error) {
+	command := commandName(url)
+	ctx, span := v.startCommandSpan(ctx, command)
+	defer span.End()
+	start := time.Now()
+	body, err := v.apiClient().postContext(ctx, url, reqBody)
+	var response *CommandResponse
+	if len(body) > 0 {
+		response = &CommandResponse{}
+		if json.Unmarshal(body, response) != nil {
+			response = nil
+		}
+	}
+	v.audit(command, reqBody, start, response, err)
+	v.observeCommand(ctx, span, command, start, response, err)
+	return body, err
 }
 
 // sendCommand sends a command to the vehicle
-func sendCommand(url string, reqBody []byte) ([]byte, error) {
-	body, err := ActiveClient.post(url, reqBody)
+func (v Vehicle) sendCommand(url string, reqBody []byte) ([]byte, error) {
+	return v.sendCommandContext(context.Background(), url, reqBody)
+}
+
+// sendCommandContext sends a command to the vehicle as part of the supplied context
+func (v Vehicle) sendCommandContext(ctx context.Context, url string, reqBody []byte) ([]byte, error) {
+	body, err := v.postCommandContext(ctx, url, reqBody)
 	if err != nil {
 		return nil, err
 	}
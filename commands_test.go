@@ -1,6 +1,7 @@
 package tesla
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -39,19 +40,21 @@ func TestCommandsSpec(t *testing.T) {
 	})
 
 	Convey("Should auto park car forward", t, func() {
-		vehicles, err := client.Vehicles()
+		capability, err := GrantMotionCapability(MotionAcknowledgement)
 		So(err, ShouldBeNil)
-		vehicle := vehicles[0]
-		err = vehicle.AutoparkForward()
+		vehicle := &Vehicle{ID: 5678, VehicleID: 456}
+		maneuver, err := vehicle.AutoparkForward(context.Background(), capability, nil)
 		So(err, ShouldBeNil)
+		So(maneuver.Stop(), ShouldBeNil)
 	})
 
-	Convey("Should auto park car forward", t, func() {
-		vehicles, err := client.Vehicles()
+	Convey("Should auto park car in reverse", t, func() {
+		capability, err := GrantMotionCapability(MotionAcknowledgement)
 		So(err, ShouldBeNil)
-		vehicle := vehicles[0]
-		err = vehicle.AutoparkReverse()
+		vehicle := &Vehicle{ID: 5678, VehicleID: 456}
+		maneuver, err := vehicle.AutoparkReverse(context.Background(), capability, nil)
 		So(err, ShouldBeNil)
+		So(maneuver.Stop(), ShouldBeNil)
 	})
 
 	Convey("Should toggle the garage door based on Homelink", t, func() {
@@ -183,11 +186,19 @@ func TestCommandsSpec(t *testing.T) {
 	})
 
 	Convey("Should start the car", t, func() {
+		capability, err := GrantMotionCapability(MotionAcknowledgement)
+		So(err, ShouldBeNil)
+		vehicle := &Vehicle{ID: 5678, VehicleID: 456}
+		err = vehicle.Start(capability, "foo")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should not start the car without a motion capability", t, func() {
 		vehicles, err := client.Vehicles()
 		So(err, ShouldBeNil)
 		vehicle := vehicles[0]
-		err = vehicle.Start("foo")
-		So(err, ShouldBeNil)
+		err = vehicle.Start(nil, "foo")
+		So(err, ShouldEqual, ErrMotionCapabilityRequired)
 	})
 
 	Convey("Should move the Pano Roof around", t, func() {
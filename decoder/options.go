@@ -0,0 +1,150 @@
+// Package decoder decodes the option codes and VINs of Tesla vehicles into human-readable
+// descriptions of their configuration
+package decoder
+
+import "strings"
+
+// Category groups related option codes
+type Category string
+
+// Option categories
+const (
+	CategoryModel       Category = "model"
+	CategoryRegion      Category = "region"
+	CategoryBattery     Category = "battery"
+	CategoryDrive       Category = "drive"
+	CategoryPaint       Category = "paint"
+	CategoryWheels      Category = "wheels"
+	CategoryRoof        Category = "roof"
+	CategoryInterior    Category = "interior"
+	CategorySeating     Category = "seating"
+	CategoryAutopilot   Category = "autopilot"
+	CategoryCharging    Category = "charging"
+	CategoryPerformance Category = "performance"
+	CategoryPackage     Category = "package"
+	CategoryUnknown     Category = "unknown"
+)
+
+// Option is a decoded option code. Options that aren't in the Options table are decoded with
+// CategoryUnknown and an empty description
+type Option struct {
+	Code        string   `json:"code"`
+	Description string   `json:"description"`
+	Category    Category `json:"category"`
+}
+
+// Options maps the known option codes to their descriptions. Tesla doesn't publish its option
+// codes, so the table covers the codes whose meaning is well established
+var Options = map[string]Option{
+	"MDLS": {"MDLS", "Model S", CategoryModel},
+	"MS03": {"MS03", "Model S (2014)", CategoryModel},
+	"MS04": {"MS04", "Model S (2016)", CategoryModel},
+	"MDLX": {"MDLX", "Model X", CategoryModel},
+	"MDL3": {"MDL3", "Model 3", CategoryModel},
+	"MDLY": {"MDLY", "Model Y", CategoryModel},
+
+	"RENA": {"RENA", "North America", CategoryRegion},
+	"RECA": {"RECA", "Canada", CategoryRegion},
+	"REEU": {"REEU", "Europe", CategoryRegion},
+	"RECN": {"RECN", "China", CategoryRegion},
+	"REAP": {"REAP", "Asia Pacific", CategoryRegion},
+	"COUS": {"COUS", "United States", CategoryRegion},
+	"COCA": {"COCA", "Canada", CategoryRegion},
+	"CODE": {"CODE", "Germany", CategoryRegion},
+	"COGB": {"COGB", "United Kingdom", CategoryRegion},
+	"CONL": {"CONL", "Netherlands", CategoryRegion},
+	"CONO": {"CONO", "Norway", CategoryRegion},
+	"DRLH": {"DRLH", "Left-hand drive", CategoryRegion},
+	"DRRH": {"DRRH", "Right-hand drive", CategoryRegion},
+
+	"BT40": {"BT40", "40 kWh battery", CategoryBattery},
+	"BT60": {"BT60", "60 kWh battery", CategoryBattery},
+	"BT70": {"BT70", "70 kWh battery", CategoryBattery},
+	"BT85": {"BT85", "85 kWh battery", CategoryBattery},
+	"BTX4": {"BTX4", "90 kWh battery", CategoryBattery},
+	"BTX5": {"BTX5", "75 kWh battery", CategoryBattery},
+	"BTX6": {"BTX6", "100 kWh battery", CategoryBattery},
+	"BTX7": {"BTX7", "75 kWh battery", CategoryBattery},
+	"BTX8": {"BTX8", "85 kWh battery", CategoryBattery},
+	"BR00": {"BR00", "No battery firmware limit", CategoryBattery},
+	"BR01": {"BR01", "Battery firmware limit", CategoryBattery},
+
+	"DV2W": {"DV2W", "Rear-wheel drive", CategoryDrive},
+	"DV4W": {"DV4W", "All-wheel drive", CategoryDrive},
+
+	"PBSB": {"PBSB", "Solid Black", CategoryPaint},
+	"PBCW": {"PBCW", "Solid White", CategoryPaint},
+	"PMBL": {"PMBL", "Obsidian Black Metallic", CategoryPaint},
+	"PMNG": {"PMNG", "Midnight Silver Metallic", CategoryPaint},
+	"PMSS": {"PMSS", "Silver Metallic", CategoryPaint},
+	"PPSB": {"PPSB", "Deep Blue Metallic", CategoryPaint},
+	"PPSW": {"PPSW", "Pearl White Multi-Coat", CategoryPaint},
+	"PPMR": {"PPMR", "Red Multi-Coat", CategoryPaint},
+
+	"WT19": {"WT19", "19\" wheels", CategoryWheels},
+	"WT21": {"WT21", "21\" wheels", CategoryWheels},
+	"WTSG": {"WTSG", "21\" grey wheels", CategoryWheels},
+
+	"RFBC": {"RFBC", "Body color roof", CategoryRoof},
+	"RFBK": {"RFBK", "Black roof", CategoryRoof},
+	"RFPO": {"RFPO", "Panoramic roof", CategoryRoof},
+	"RFP2": {"RFP2", "Sunroof", CategoryRoof},
+
+	"IDPB": {"IDPB", "Piano Black decor", CategoryInterior},
+	"YF01": {"YF01", "Matching yacht floor", CategoryInterior},
+
+	"ME02": {"ME02", "Memory seats", CategorySeating},
+	"TR00": {"TR00", "No third row seats", CategorySeating},
+	"TR01": {"TR01", "Third row seats", CategorySeating},
+
+	"AP00": {"AP00", "No Autopilot", CategoryAutopilot},
+	"AP01": {"AP01", "Autopilot", CategoryAutopilot},
+	"APF1": {"APF1", "Enhanced Autopilot", CategoryAutopilot},
+	"APF2": {"APF2", "Full Self-Driving Capability", CategoryAutopilot},
+	"APH2": {"APH2", "Autopilot hardware 2.0", CategoryAutopilot},
+	"APH3": {"APH3", "Autopilot hardware 2.5", CategoryAutopilot},
+	"APH4": {"APH4", "Autopilot hardware 3.0", CategoryAutopilot},
+
+	"CH00": {"CH00", "Standard charger", CategoryCharging},
+	"CH01": {"CH01", "Dual chargers", CategoryCharging},
+	"HP00": {"HP00", "No High Power Wall Connector", CategoryCharging},
+	"HP01": {"HP01", "High Power Wall Connector", CategoryCharging},
+	"SC00": {"SC00", "No Supercharging", CategoryCharging},
+	"SC01": {"SC01", "Supercharging enabled", CategoryCharging},
+	"SC04": {"SC04", "Pay-per-use Supercharging", CategoryCharging},
+	"SC05": {"SC05", "Free unlimited Supercharging", CategoryCharging},
+
+	"PF00": {"PF00", "No performance package", CategoryPerformance},
+	"PF01": {"PF01", "Performance package", CategoryPerformance},
+	"SU00": {"SU00", "Standard suspension", CategoryPerformance},
+	"SU01": {"SU01", "Smart Air Suspension", CategoryPerformance},
+
+	"CW00": {"CW00", "No cold weather package", CategoryPackage},
+	"CW02": {"CW02", "Cold weather package", CategoryPackage},
+	"FG02": {"FG02", "Fog lamps", CategoryPackage},
+	"LP01": {"LP01", "Premium lighting package", CategoryPackage},
+	"PS01": {"PS01", "Parcel shelf", CategoryPackage},
+}
+
+// LookupOption returns the option for the code, and whether the code is known
+func LookupOption(code string) (Option, bool) {
+	option, ok := Options[strings.ToUpper(code)]
+	return option, ok
+}
+
+// DecodeOptions decodes a comma-separated list of option codes, as reported by the API, in order
+func DecodeOptions(codes string) []Option {
+	options := []Option{}
+	for _, code := range strings.Split(codes, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		option, ok := Options[code]
+		if !ok {
+			option = Option{Code: code, Category: CategoryUnknown}
+		}
+		options = append(options, option)
+	}
+	return options
+}
@@ -0,0 +1,37 @@
+package decoder
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOptionsSpec(t *testing.T) {
+	Convey("Should look up option codes", t, func() {
+		option, ok := LookupOption("BT85")
+		So(ok, ShouldBeTrue)
+		So(option.Description, ShouldEqual, "85 kWh battery")
+		So(option.Category, ShouldEqual, CategoryBattery)
+		option, ok = LookupOption("pbsb")
+		So(ok, ShouldBeTrue)
+		So(option.Description, ShouldEqual, "Solid Black")
+		_, ok = LookupOption("ZZZZ")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should decode a list of option codes in order", t, func() {
+		options := DecodeOptions("MDLS, DV4W,,X001")
+		So(len(options), ShouldEqual, 3)
+		So(options[0].Description, ShouldEqual, "Model S")
+		So(options[1].Category, ShouldEqual, CategoryDrive)
+		So(options[2], ShouldResemble, Option{Code: "X001", Category: CategoryUnknown})
+		So(DecodeOptions(""), ShouldBeEmpty)
+	})
+
+	Convey("Every option should be keyed by its own code", t, func() {
+		for code, option := range Options {
+			So(option.Code, ShouldEqual, code)
+			So(option.Description, ShouldNotBeEmpty)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package decoder
+
+// Spec is a summary of a vehicle's configuration, decoded from its VIN and option codes. The
+// model comes from the VIN, or from the option codes if the VIN doesn't identify it. Battery,
+// Drive, Paint, Wheels and Roof are the descriptions of the first known option in each category,
+// with the battery falling back to the VIN's battery type when no battery option is known
+type Spec struct {
+	VIN       *VIN     `json:"vin"`
+	Model     string   `json:"model"`
+	ModelYear int      `json:"model_year"`
+	Battery   string   `json:"battery"`
+	Drive     string   `json:"drive"`
+	Paint     string   `json:"paint"`
+	Wheels    string   `json:"wheels"`
+	Roof      string   `json:"roof"`
+	Options   []Option `json:"options"`
+}
+
+// Decode decodes the VIN and the comma-separated option codes into a spec
+func Decode(vin, optionCodes string) (*Spec, error) {
+	decoded, err := DecodeVIN(vin)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{
+		VIN:       decoded,
+		Model:     decoded.Model,
+		ModelYear: decoded.ModelYear,
+		Options:   DecodeOptions(optionCodes),
+	}
+	fields := map[Category]*string{
+		CategoryModel:   &spec.Model,
+		CategoryBattery: &spec.Battery,
+		CategoryDrive:   &spec.Drive,
+		CategoryPaint:   &spec.Paint,
+		CategoryWheels:  &spec.Wheels,
+		CategoryRoof:    &spec.Roof,
+	}
+	for _, option := range spec.Options {
+		if field, ok := fields[option.Category]; ok && *field == "" {
+			*field = option.Description
+		}
+	}
+	if spec.Battery == "" {
+		spec.Battery = decoded.Battery
+	}
+	return spec, nil
+}
+
+// Find returns the first option in the category, and whether there is one
+func (s Spec) Find(category Category) (Option, bool) {
+	for _, option := range s.Options {
+		if option.Category == category {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
@@ -0,0 +1,39 @@
+package decoder
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecodeSpec(t *testing.T) {
+	Convey("Should summarize the VIN and option codes", t, func() {
+		spec, err := Decode("5YJSA1E27HF000001", "MS04,RENA,BT85,PPSW,DV2W,WT21,RFPO,X001")
+		So(err, ShouldBeNil)
+		So(spec.Model, ShouldEqual, "Model S")
+		So(spec.ModelYear, ShouldEqual, 2017)
+		So(spec.Battery, ShouldEqual, "85 kWh battery")
+		So(spec.Drive, ShouldEqual, "Rear-wheel drive")
+		So(spec.Paint, ShouldEqual, "Pearl White Multi-Coat")
+		So(spec.Wheels, ShouldEqual, "21\" wheels")
+		So(spec.Roof, ShouldEqual, "Panoramic roof")
+		region, ok := spec.Find(CategoryRegion)
+		So(ok, ShouldBeTrue)
+		So(region.Code, ShouldEqual, "RENA")
+		_, ok = spec.Find(CategoryAutopilot)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should take the model from the option codes when the VIN doesn't identify it", t, func() {
+		spec, err := Decode("5YJZA1E27HF000001", "MDLX")
+		So(err, ShouldBeNil)
+		So(spec.VIN.Model, ShouldBeEmpty)
+		So(spec.Model, ShouldEqual, "Model X")
+		So(spec.Battery, ShouldEqual, "Lithium-ion")
+	})
+
+	Convey("Should return an error for an invalid VIN", t, func() {
+		_, err := Decode("abc123", "MDLS")
+		So(err, ShouldEqual, ErrInvalidVIN)
+	})
+}
@@ -0,0 +1,165 @@
+package decoder
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidVIN is returned when a VIN isn't 17 valid characters
+	ErrInvalidVIN = errors.New("invalid VIN")
+	// ErrInvalidCheckDigit is returned when a VIN's check digit doesn't match the rest of the VIN
+	ErrInvalidCheckDigit = errors.New("VIN check digit doesn't match")
+)
+
+// VIN is a decoded vehicle identification number. Fields that can't be decoded are empty
+type VIN struct {
+	VIN          string `json:"vin"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	Body         string `json:"body"`
+	Battery      string `json:"battery"`
+	Motor        string `json:"motor"`
+	ModelYear    int    `json:"model_year"`
+	Plant        string `json:"plant"`
+	Serial       string `json:"serial"`
+	// CheckDigitValid is false when the check digit doesn't match. Only vehicles built for North
+	// America are required to have a valid check digit
+	CheckDigitValid bool `json:"check_digit_valid"`
+}
+
+var (
+	manufacturers = map[string]string{
+		"5YJ": "Tesla, Inc. (Fremont)",
+		"7SA": "Tesla, Inc.",
+		"7G2": "Tesla, Inc. (trucks)",
+		"LRW": "Tesla Shanghai",
+		"XP7": "Tesla Berlin",
+		"SFZ": "Tesla Roadster",
+	}
+	models = map[byte]string{
+		'S': "Model S",
+		'X': "Model X",
+		'3': "Model 3",
+		'Y': "Model Y",
+		'R': "Roadster",
+		'C': "Cybertruck",
+		'T': "Semi",
+	}
+	bodies = map[byte]string{
+		'A': "5-door hatchback, left-hand drive",
+		'B': "5-door hatchback, right-hand drive",
+		'C': "5-door MPV, left-hand drive",
+		'D': "5-door MPV, right-hand drive",
+		'E': "4-door sedan, left-hand drive",
+		'F': "4-door sedan, right-hand drive",
+		'G': "5-door MPV, left-hand drive",
+		'H': "5-door MPV, right-hand drive",
+	}
+	batteries = map[byte]string{
+		'E': "Lithium-ion",
+		'F': "Lithium iron phosphate",
+		'H': "Lithium-ion, high capacity",
+		'S': "Lithium-ion, standard capacity",
+		'V': "Lithium-ion, ultra high capacity",
+	}
+	motors = map[byte]string{
+		'1': "Single motor",
+		'2': "Dual motor",
+		'3': "Single motor, performance",
+		'4': "Dual motor, performance",
+		'5': "Dual motor, P2",
+		'6': "Dual motor, P2 performance",
+		'A': "Single motor",
+		'B': "Dual motor",
+		'C': "Dual motor, performance",
+		'D': "Single motor",
+		'E': "Dual motor",
+		'F': "Dual motor, performance",
+	}
+	plants = map[byte]string{
+		'A': "Austin, Texas",
+		'B': "Berlin, Germany",
+		'C': "Shanghai, China",
+		'F': "Fremont, California",
+		'P': "Palo Alto, California",
+	}
+	// The model year codes that follow 2009, skipping I, O, Q, U and Z
+	modelYears = "ABCDEFGHJKLMNPRSTVWXY"
+	// The values of letters in the check digit calculation
+	transliterations = map[byte]int{
+		'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+		'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+		'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+	}
+	checkDigitWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// DecodeVIN decodes a VIN. ErrInvalidVIN is returned if it isn't 17 valid characters; a
+// mismatched check digit is reported by CheckDigitValid rather than as an error
+func DecodeVIN(vin string) (*VIN, error) {
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	checkDigit, err := calculateCheckDigit(vin)
+	if err != nil {
+		return nil, err
+	}
+	return &VIN{
+		VIN:             vin,
+		Manufacturer:    manufacturers[vin[:3]],
+		Model:           models[vin[3]],
+		Body:            bodies[vin[4]],
+		Battery:         batteries[vin[6]],
+		Motor:           motors[vin[7]],
+		ModelYear:       modelYear(vin[9]),
+		Plant:           plants[vin[10]],
+		Serial:          vin[11:],
+		CheckDigitValid: vin[8] == checkDigit,
+	}, nil
+}
+
+// ValidateVIN returns ErrInvalidVIN if the VIN isn't 17 valid characters, or
+// ErrInvalidCheckDigit if its check digit doesn't match
+func ValidateVIN(vin string) error {
+	decoded, err := DecodeVIN(vin)
+	if err != nil {
+		return err
+	}
+	if !decoded.CheckDigitValid {
+		return ErrInvalidCheckDigit
+	}
+	return nil
+}
+
+// Calculates the check digit of a VIN, which is the weighted sum of its characters modulo 11
+func calculateCheckDigit(vin string) (byte, error) {
+	if len(vin) != 17 {
+		return 0, ErrInvalidVIN
+	}
+	sum := 0
+	for i := 0; i < len(vin); i++ {
+		c := vin[i]
+		value, ok := transliterations[c]
+		if c >= '0' && c <= '9' {
+			value, ok = int(c-'0'), true
+		}
+		if !ok {
+			return 0, ErrInvalidVIN
+		}
+		sum += value * checkDigitWeights[i]
+	}
+	if sum%11 == 10 {
+		return 'X', nil
+	}
+	return byte('0' + sum%11), nil
+}
+
+// Decodes the model year code, which repeats every 30 years. Digits are taken to be 2001 to 2009
+func modelYear(c byte) int {
+	if c >= '1' && c <= '9' {
+		return 2000 + int(c-'0')
+	}
+	if i := strings.IndexByte(modelYears, c); i >= 0 {
+		return 2010 + i
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package decoder
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVINSpec(t *testing.T) {
+	Convey("Should decode a VIN", t, func() {
+		vin, err := DecodeVIN("5yj3e1ea0kf000316")
+		So(err, ShouldBeNil)
+		So(vin.VIN, ShouldEqual, "5YJ3E1EA0KF000316")
+		So(vin.Manufacturer, ShouldEqual, "Tesla, Inc. (Fremont)")
+		So(vin.Model, ShouldEqual, "Model 3")
+		So(vin.Body, ShouldEqual, "4-door sedan, left-hand drive")
+		So(vin.Battery, ShouldEqual, "Lithium-ion")
+		So(vin.Motor, ShouldEqual, "Single motor")
+		So(vin.ModelYear, ShouldEqual, 2019)
+		So(vin.Plant, ShouldEqual, "Fremont, California")
+		So(vin.Serial, ShouldEqual, "000316")
+		So(vin.CheckDigitValid, ShouldBeTrue)
+
+		vin, err = DecodeVIN("7SAYGDEE2PF000123")
+		So(err, ShouldBeNil)
+		So(vin.Model, ShouldEqual, "Model Y")
+		So(vin.ModelYear, ShouldEqual, 2023)
+		So(vin.CheckDigitValid, ShouldBeTrue)
+	})
+
+	Convey("Should decode the battery type from the seventh character", t, func() {
+		vin, err := DecodeVIN("LRW3E7FA0MC000001")
+		So(err, ShouldBeNil)
+		So(vin.Battery, ShouldEqual, "Lithium iron phosphate")
+		vin, err = DecodeVIN("5YJSA7H27HF000001")
+		So(err, ShouldBeNil)
+		So(vin.Battery, ShouldEqual, "Lithium-ion, high capacity")
+		vin, err = DecodeVIN("5YJSA1127HF000001")
+		So(err, ShouldBeNil)
+		So(vin.Battery, ShouldBeEmpty)
+	})
+
+	Convey("Should validate the check digit", t, func() {
+		So(ValidateVIN("5YJSA1E27HF000001"), ShouldBeNil)
+		So(ValidateVIN("5YJSA1E28HF000001"), ShouldEqual, ErrInvalidCheckDigit)
+		vin, err := DecodeVIN("5YJSA1E28HF000001")
+		So(err, ShouldBeNil)
+		So(vin.CheckDigitValid, ShouldBeFalse)
+	})
+
+	Convey("Should reject malformed VINs", t, func() {
+		_, err := DecodeVIN("abc123")
+		So(err, ShouldEqual, ErrInvalidVIN)
+		_, err = DecodeVIN("5YJSA1E27HF00000O")
+		So(err, ShouldEqual, ErrInvalidVIN)
+		So(ValidateVIN("5YJSA1E27HF0000011"), ShouldEqual, ErrInvalidVIN)
+	})
+
+	Convey("Should decode the model year", t, func() {
+		So(modelYear('8'), ShouldEqual, 2008)
+		So(modelYear('A'), ShouldEqual, 2010)
+		So(modelYear('S'), ShouldEqual, 2025)
+		So(modelYear('Y'), ShouldEqual, 2030)
+		So(modelYear('Z'), ShouldEqual, 0)
+	})
+}
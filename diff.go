@@ -0,0 +1,260 @@
+package tesla
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Change is a single field that differs between two vehicle data snapshots. Path is the
+// dot-separated JSON path of the field, such as "charge_state.battery_level", and Old and New
+// are its decoded JSON values, which are nil if the field was null or missing
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// Differ compares vehicle data snapshots field by field
+type Differ struct {
+	// Tolerances maps a JSON path to the amount a numeric field must change by to be reported
+	Tolerances map[string]float64
+	// Ignore lists fields that are never reported, matched against either the full JSON path or
+	// the field name, so "timestamp" ignores the timestamp of every section
+	Ignore []string
+}
+
+// DefaultDiffer ignores the per-section timestamps and the noise in the range, temperature
+// and location readings
+var DefaultDiffer = &Differ{
+	Tolerances: map[string]float64{
+		"charge_state.battery_range":       0.5,
+		"charge_state.est_battery_range":   0.5,
+		"charge_state.ideal_battery_range": 0.5,
+		"climate_state.inside_temp":        0.5,
+		"climate_state.outside_temp":       0.5,
+		"drive_state.latitude":             0.0001,
+		"drive_state.longitude":            0.0001,
+		"drive_state.native_latitude":      0.0001,
+		"drive_state.native_longitude":     0.0001,
+	},
+	Ignore: []string{"timestamp", "gps_as_of"},
+}
+
+// ChangeEmitter diffs each vehicle data snapshot it's given against the previous one, and
+// calls the handlers registered for the fields that changed
+type ChangeEmitter struct {
+	// Differ compares the snapshots, and defaults to DefaultDiffer
+	Differ *Differ
+
+	mu       sync.Mutex
+	last     *VehicleData
+	handlers []changeHandler
+}
+
+type changeHandler struct {
+	prefix  string
+	handler func(Change)
+}
+
+// Diff compares two vehicle data snapshots using DefaultDiffer
+func Diff(old, new *VehicleData) ([]Change, error) {
+	return DefaultDiffer.Diff(old, new)
+}
+
+// Diff compares two vehicle data snapshots and returns the fields that changed, sorted by path.
+// Sections and fields missing from either snapshot, such as those left out of a selective query
+// or the location left out of a drive state, aren't compared. Snapshots are compared as they
+// encode, leaving out the zero-valued fields the API didn't send, so changes made to a decoded
+// snapshot are compared too
+func (d *Differ) Diff(old, new *VehicleData) ([]Change, error) {
+	oldFields, err := snapshotFields(old)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := snapshotFields(new)
+	if err != nil {
+		return nil, err
+	}
+	changes := []Change{}
+	d.diffObjects("", oldFields, newFields, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Compares two decoded JSON objects, appending the fields that changed
+func (d *Differ) diffObjects(prefix string, old, new map[string]interface{}, changes *[]Change) {
+	keys := map[string]bool{}
+	for key := range old {
+		keys[key] = true
+	}
+	for key := range new {
+		keys[key] = true
+	}
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if d.ignored(path, key) {
+			continue
+		}
+		oldValue, oldSent := old[key]
+		newValue, newSent := new[key]
+		oldObject, oldIsObject := oldValue.(map[string]interface{})
+		newObject, newIsObject := newValue.(map[string]interface{})
+		switch {
+		case !oldSent || !newSent:
+		case oldIsObject && newIsObject:
+			d.diffObjects(path, oldObject, newObject, changes)
+		case oldIsObject && newValue == nil, newIsObject && oldValue == nil:
+		case d.equal(path, oldValue, newValue):
+		default:
+			*changes = append(*changes, Change{Path: path, Old: oldValue, New: newValue})
+		}
+	}
+}
+
+// Indicates whether two decoded JSON values are equal, within the tolerance for the path
+func (d *Differ) equal(path string, old, new interface{}) bool {
+	oldNumber, oldIsNumber := old.(float64)
+	newNumber, newIsNumber := new.(float64)
+	if oldIsNumber && newIsNumber {
+		return math.Abs(newNumber-oldNumber) <= d.Tolerances[path]
+	}
+	return reflect.DeepEqual(old, new)
+}
+
+// Indicates whether the field is ignored
+func (d *Differ) ignored(path, key string) bool {
+	for _, ignore := range d.Ignore {
+		if ignore == path || ignore == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Decodes a snapshot into its JSON fields. Fields of a snapshot decoded from JSON that the API
+// left out, and still hold their zero value, are dropped, and those it sent as null are null
+// until they are set
+func snapshotFields(data *VehicleData) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if data == nil {
+		return fields, nil
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if data.present != nil {
+		pruneFields("", fields, data.present)
+	}
+	return fields, nil
+}
+
+// Records the path of every field in a decoded JSON object, and whether it had a value
+func recordPaths(prefix string, object map[string]interface{}, present map[string]bool) {
+	for key, value := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		present[path] = value != nil
+		if child, ok := value.(map[string]interface{}); ok {
+			recordPaths(path, child, present)
+		}
+	}
+}
+
+// Drops the zero-valued fields that weren't present when the snapshot was decoded, and restores
+// the nulls of those that were null
+func pruneFields(prefix string, object map[string]interface{}, present map[string]bool) {
+	for key, value := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		hadValue, sent := present[path]
+		if child, ok := value.(map[string]interface{}); ok {
+			pruneFields(path, child, present)
+			if !sent && len(child) == 0 {
+				delete(object, key)
+			}
+			continue
+		}
+		if value == nil || !reflect.ValueOf(value).IsZero() {
+			continue
+		}
+		switch {
+		case !sent:
+			delete(object, key)
+		case !hadValue:
+			object[key] = nil
+		}
+	}
+}
+
+// NewChangeEmitter returns an emitter which compares snapshots using DefaultDiffer
+func NewChangeEmitter() *ChangeEmitter {
+	return &ChangeEmitter{Differ: DefaultDiffer}
+}
+
+// On registers a handler for changes to the field at path, or to any field beneath it if path
+// is a section such as "charge_state". An empty path matches every change
+func (e *ChangeEmitter) On(path string, handler func(Change)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.handlers = append(e.handlers, changeHandler{prefix: path, handler: handler})
+}
+
+// Update diffs the snapshot against the previous one, emits the changes and returns them. The
+// first snapshot only sets the baseline, so no changes are emitted for it
+func (e *ChangeEmitter) Update(data *VehicleData) ([]Change, error) {
+	e.mu.Lock()
+	last := e.last
+	e.last = data
+	differ := e.Differ
+	e.mu.Unlock()
+	if last == nil {
+		return []Change{}, nil
+	}
+	if differ == nil {
+		differ = DefaultDiffer
+	}
+	changes, err := differ.Diff(last, data)
+	if err != nil {
+		return nil, err
+	}
+	e.Emit(changes)
+	return changes, nil
+}
+
+// Emit calls the registered handlers for each of the changes, in order
+func (e *ChangeEmitter) Emit(changes []Change) {
+	e.mu.Lock()
+	handlers := make([]changeHandler, len(e.handlers))
+	copy(handlers, e.handlers)
+	e.mu.Unlock()
+	for _, change := range changes {
+		for _, h := range handlers {
+			if h.matches(change.Path) {
+				h.handler(change)
+			}
+		}
+	}
+}
+
+// Indicates whether the handler is registered for the path
+func (h changeHandler) matches(path string) bool {
+	return h.prefix == "" || path == h.prefix || strings.HasPrefix(path, h.prefix+".")
+}
@@ -0,0 +1,125 @@
+package tesla
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ChangedVehicleDataJSON = `{"response":{"id":1234,"vin":"abc123","state":"online","charge_state":{"battery_level":79,"battery_range":235.7,"timestamp":1543187071731},"climate_state":{"inside_temp":23.5,"timestamp":1543187071731},"drive_state":{"shift_state":"D","speed":25,"heading":57,"timestamp":1543187071731},"gui_settings":{"gui_distance_units":"mi/hr"},"vehicle_config":{"car_type":"models2"},"vehicle_state":{"locked":false,"odometer":3738.84633}}}`
+)
+
+func TestDiffSpec(t *testing.T) {
+	decode := func(body string) *VehicleData {
+		vehicleData := &struct {
+			Response *VehicleData `json:"response"`
+		}{}
+		err := json.Unmarshal([]byte(body), vehicleData)
+		So(err, ShouldBeNil)
+		return vehicleData.Response
+	}
+
+	Convey("Should report the fields that changed between snapshots", t, func() {
+		changes, err := Diff(decode(VehicleDataJSON), decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		paths := []string{}
+		for _, change := range changes {
+			paths = append(paths, change.Path)
+		}
+		So(paths, ShouldResemble, []string{
+			"charge_state.battery_level",
+			"climate_state.inside_temp",
+			"drive_state.shift_state",
+			"drive_state.speed",
+			"vehicle_state.locked",
+		})
+		So(changes[0].Old, ShouldEqual, 80)
+		So(changes[0].New, ShouldEqual, 79)
+		So(changes[2].Old, ShouldBeNil)
+		So(changes[2].New, ShouldEqual, "D")
+	})
+
+	Convey("Should report changes within the tolerance when the tolerance is removed", t, func() {
+		differ := &Differ{Ignore: []string{"timestamp"}}
+		changes, err := differ.Diff(decode(VehicleDataJSON), decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		So(changes[1].Path, ShouldEqual, "charge_state.battery_range")
+	})
+
+	Convey("Should skip sections missing from either snapshot", t, func() {
+		changes, err := Diff(decode(LocationDataJSON), decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		for _, change := range changes {
+			So(change.Path, ShouldNotStartWith, "climate_state")
+			So(change.Path, ShouldNotStartWith, "vehicle_state")
+		}
+	})
+
+	Convey("Should skip fields missing from either snapshot", t, func() {
+		changes, err := Diff(decode(LocationDataJSON), decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		for _, change := range changes {
+			So(change.Path, ShouldNotStartWith, "drive_state.latitude")
+			So(change.Path, ShouldNotStartWith, "drive_state.longitude")
+		}
+		changes, err = Diff(decode(LocationDataJSON), decode(strings.Replace(LocationDataJSON, `"latitude":35.1`, `"latitude":35.2`, 1)))
+		So(err, ShouldBeNil)
+		So(changes, ShouldHaveLength, 1)
+		So(changes[0].Path, ShouldEqual, "drive_state.latitude")
+	})
+
+	Convey("Should compare snapshots that were changed after they were decoded", t, func() {
+		old, new := decode(VehicleDataJSON), decode(VehicleDataJSON)
+		new.ChargeState = &ChargeState{BatteryLevel: 81}
+		changes, err := Diff(old, new)
+		So(err, ShouldBeNil)
+		So(changes, ShouldNotBeEmpty)
+		So(changes[0].Path, ShouldEqual, "charge_state.battery_level")
+		So(changes[0].New, ShouldEqual, 81)
+
+		new = decode(LocationDataJSON)
+		new.DriveState.Latitude = 35.2
+		changes, err = Diff(decode(LocationDataJSON), new)
+		So(err, ShouldBeNil)
+		So(changes, ShouldHaveLength, 1)
+		So(changes[0].Path, ShouldEqual, "drive_state.latitude")
+	})
+
+	Convey("Should compare snapshots that weren't decoded from JSON as they encode", t, func() {
+		old := &VehicleData{ChargeState: &ChargeState{BatteryLevel: 80}}
+		new := &VehicleData{ChargeState: &ChargeState{BatteryLevel: 81}}
+		changes, err := Diff(old, new)
+		So(err, ShouldBeNil)
+		So(changes, ShouldHaveLength, 1)
+		So(changes[0].Path, ShouldEqual, "charge_state.battery_level")
+	})
+
+	Convey("Should emit changes to the registered handlers", t, func() {
+		emitter := NewChangeEmitter()
+		all := []Change{}
+		charge := []Change{}
+		locked := []Change{}
+		emitter.On("", func(change Change) { all = append(all, change) })
+		emitter.On("charge_state", func(change Change) { charge = append(charge, change) })
+		emitter.On("vehicle_state.locked", func(change Change) { locked = append(locked, change) })
+
+		changes, err := emitter.Update(decode(VehicleDataJSON))
+		So(err, ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+		changes, err = emitter.Update(decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		So(len(changes), ShouldEqual, 5)
+		So(len(all), ShouldEqual, 5)
+		So(len(charge), ShouldEqual, 1)
+		So(len(locked), ShouldEqual, 1)
+		So(locked[0].New, ShouldEqual, false)
+
+		changes, err = emitter.Update(decode(ChangedVehicleDataJSON))
+		So(err, ShouldBeNil)
+		So(changes, ShouldBeEmpty)
+		So(len(all), ShouldEqual, 5)
+	})
+}
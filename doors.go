@@ -0,0 +1,97 @@
+package tesla
+
+import "time"
+
+// PSIPerBar converts tire pressures reported in bar to PSI
+const PSIPerBar = 14.5038
+
+// DoorState is a typed view of which of the vehicle's doors and trunks are open
+type DoorState struct {
+	DriverFront    bool
+	DriverRear     bool
+	PassengerFront bool
+	PassengerRear  bool
+	FrontTrunk     bool
+	RearTrunk      bool
+}
+
+// TirePressure is the TPMS reading for a single tire
+type TirePressure struct {
+	// Bar is the pressure in bar, or zero if Known is false
+	Bar         float64
+	Known       bool
+	SoftWarning bool
+	HardWarning bool
+	LastSeen    time.Time
+}
+
+// TireState is a typed view of the vehicle's TPMS readings
+type TireState struct {
+	FrontLeft  TirePressure
+	FrontRight TirePressure
+	RearLeft   TirePressure
+	RearRight  TirePressure
+	// RecommendedFrontBar and RecommendedRearBar are the recommended cold pressures in bar
+	RecommendedFrontBar float64
+	RecommendedRearBar  float64
+}
+
+// AllClosed indicates whether every door and both trunks are closed
+func (d DoorState) AllClosed() bool {
+	return !d.DriverFront && !d.DriverRear && !d.PassengerFront && !d.PassengerRear && !d.FrontTrunk && !d.RearTrunk
+}
+
+// Doors returns which of the vehicle's doors and trunks are open
+func (s VehicleState) Doors() DoorState {
+	return DoorState{
+		DriverFront:    s.Df != 0,
+		DriverRear:     s.Dr != 0,
+		PassengerFront: s.Pf != 0,
+		PassengerRear:  s.Pr != 0,
+		FrontTrunk:     s.Ft != 0,
+		RearTrunk:      s.Rt != 0,
+	}
+}
+
+// PSI returns the tire pressure in PSI
+func (p TirePressure) PSI() float64 {
+	return p.Bar * PSIPerBar
+}
+
+// Warning indicates whether the vehicle has raised a soft or hard warning for the tire
+func (p TirePressure) Warning() bool {
+	return p.SoftWarning || p.HardWarning
+}
+
+// AnyWarning indicates whether the vehicle has raised a warning for any of the tires
+func (t TireState) AnyWarning() bool {
+	return t.FrontLeft.Warning() || t.FrontRight.Warning() || t.RearLeft.Warning() || t.RearRight.Warning()
+}
+
+// Tires returns the vehicle's TPMS readings. Tires the vehicle hasn't reported a pressure
+// for are returned with Known set to false
+func (s VehicleState) Tires() TireState {
+	tires := TireState{
+		FrontLeft:  tirePressure(s.TpmsPressureFl, s.TpmsLastSeenPressureTimeFl, s.TpmsSoftWarningFl, s.TpmsHardWarningFl),
+		FrontRight: tirePressure(s.TpmsPressureFr, s.TpmsLastSeenPressureTimeFr, s.TpmsSoftWarningFr, s.TpmsHardWarningFr),
+		RearLeft:   tirePressure(s.TpmsPressureRl, s.TpmsLastSeenPressureTimeRl, s.TpmsSoftWarningRl, s.TpmsHardWarningRl),
+		RearRight:  tirePressure(s.TpmsPressureRr, s.TpmsLastSeenPressureTimeRr, s.TpmsSoftWarningRr, s.TpmsHardWarningRr),
+	}
+	if s.TpmsRcpFrontValue != nil {
+		tires.RecommendedFrontBar = *s.TpmsRcpFrontValue
+	}
+	if s.TpmsRcpRearValue != nil {
+		tires.RecommendedRearBar = *s.TpmsRcpRearValue
+	}
+	return tires
+}
+
+// Builds the reading for a single tire from the raw TPMS fields
+func tirePressure(pressure *float64, lastSeen *int64, soft, hard bool) TirePressure {
+	tire := TirePressure{SoftWarning: soft, HardWarning: hard, LastSeen: optionalEpochTime(lastSeen)}
+	if pressure != nil {
+		tire.Bar = *pressure
+		tire.Known = true
+	}
+	return tire
+}
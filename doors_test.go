@@ -0,0 +1,61 @@
+package tesla
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	FullVehicleStateJSON = `{"response":{"df":1,"dr":0,"pf":0,"pr":0,"ft":0,"rt":1,"dashcam_state":"Recording","dashcam_clip_save_available":true,"santa_mode":1,"service_mode":false,"sun_roof_percent_open":null,"vehicle_self_test_progress":0,"vehicle_self_test_requested":false,"webcam_available":true,"media_info":{"audio_volume":2.6667,"media_playback_status":"Playing","now_playing_source":"Spotify","now_playing_title":"Boombox"},"software_update":{"scheduled_time_ms":1692390000000,"status":"scheduled","warning_time_remaining_ms":300000},"tpms_pressure_fl":2.9,"tpms_pressure_fr":2.875,"tpms_pressure_rl":2.95,"tpms_pressure_rr":null,"tpms_rcp_front_value":2.9,"tpms_rcp_rear_value":2.9,"tpms_soft_warning_fr":true,"tpms_hard_warning_fl":false,"tpms_last_seen_pressure_time_fl":1692380000,"tpms_last_seen_pressure_time_rr":null}}`
+)
+
+func TestDoorsSpec(t *testing.T) {
+	Convey("Should read the door and trunk states", t, func() {
+		stateRequest := &StateRequest{}
+		err := json.Unmarshal([]byte(VehicleStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		So(stateRequest.Response.VehicleState.Doors().AllClosed(), ShouldBeTrue)
+
+		err = json.Unmarshal([]byte(FullVehicleStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		doors := stateRequest.Response.VehicleState.Doors()
+		So(doors.DriverFront, ShouldBeTrue)
+		So(doors.PassengerFront, ShouldBeFalse)
+		So(doors.RearTrunk, ShouldBeTrue)
+		So(doors.AllClosed(), ShouldBeFalse)
+	})
+
+	Convey("Should read the TPMS readings", t, func() {
+		stateRequest := &StateRequest{}
+		err := json.Unmarshal([]byte(FullVehicleStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		tires := stateRequest.Response.VehicleState.Tires()
+		So(tires.FrontLeft.Known, ShouldBeTrue)
+		So(tires.FrontLeft.Bar, ShouldEqual, 2.9)
+		So(tires.FrontLeft.PSI(), ShouldAlmostEqual, 42.06, 0.01)
+		So(tires.FrontLeft.LastSeen.Unix(), ShouldEqual, 1692380000)
+		So(tires.FrontRight.Bar, ShouldEqual, 2.875)
+		So(tires.FrontRight.SoftWarning, ShouldBeTrue)
+		So(tires.RearRight.Known, ShouldBeFalse)
+		So(tires.RearRight.LastSeen.IsZero(), ShouldBeTrue)
+		So(tires.RecommendedRearBar, ShouldEqual, 2.9)
+		So(tires.AnyWarning(), ShouldBeTrue)
+	})
+
+	Convey("Should read the vehicle-specific state fields", t, func() {
+		stateRequest := &StateRequest{}
+		err := json.Unmarshal([]byte(FullVehicleStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		vehicleState := stateRequest.Response.VehicleState
+		So(vehicleState.DashcamState, ShouldEqual, "Recording")
+		So(vehicleState.DashcamClipSaveAvailable, ShouldBeTrue)
+		So(vehicleState.SantaMode, ShouldEqual, 1)
+		So(vehicleState.SunRoofPercentOpen, ShouldBeNil)
+		So(vehicleState.WebcamAvailable, ShouldBeTrue)
+		So(vehicleState.MediaInfo.NowPlayingTitle, ShouldEqual, "Boombox")
+		So(vehicleState.SoftwareUpdate.ScheduledTimeMs, ShouldEqual, 1692390000000)
+		So(vehicleState.SoftwareUpdate.WarningTimeRemainingMs, ShouldEqual, 300000)
+	})
+}
@@ -52,7 +52,7 @@ func main() {
 	//fmt.Println(vehicle.UnlockDoors())
 	//fmt.Println(vehicle.LockDoors())
 	//fmt.Println(vehicle.SetTemperature(21.0, 21.0))
-	//fmt.Println(vehicle.Start(os.Getenv("TESLA_PASSWORD")))
+	//fmt.Println(vehicle.Start(capability, os.Getenv("TESLA_PASSWORD")))
 	//fmt.Println(vehicle.OpenTrunk("rear"))
 	//fmt.Println(vehicle.OpenTrunk("front"))
 	//fmt.Println(vehicle.MovePanoRoof("vent", 0))
@@ -62,8 +62,9 @@ func main() {
 	//fmt.Println(vehicle.TriggerHomelink())
 
 	// Take care with these, as the car will move
-	//fmt.Println(vehicle.AutoparkForward())
-	//fmt.Println(vehicle.AutoparkReverse())
+	//capability, _ := tesla.GrantMotionCapability(tesla.MotionAcknowledgement)
+	//fmt.Println(vehicle.AutoparkForward(context.Background(), capability, nil))
+	//fmt.Println(vehicle.AutoparkReverse(context.Background(), capability, nil))
 	// Take care with these, as the car will move
 
 	// Stream vehicle events
@@ -0,0 +1,192 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultFleetConcurrency is the number of vehicles a fleet commands at once when Concurrency isn't set
+const DefaultFleetConcurrency = 4
+
+// WakePollInterval is how often a waking vehicle is checked until it comes online
+var WakePollInterval = 2 * time.Second
+
+// ErrWakeTimeout is returned when a vehicle doesn't come online before the wake timeout
+var ErrWakeTimeout = errors.New("vehicle did not wake up before the timeout")
+
+// Fleet runs commands against many vehicles concurrently. At most Concurrency vehicles are
+// commanded at once. If WakeUp is set, vehicles that aren't online are woken first, waiting up
+// to WakeTimeout for each to come online
+type Fleet struct {
+	Vehicles    []*Vehicle
+	Concurrency int
+	WakeUp      bool
+	WakeTimeout time.Duration
+}
+
+// FleetResult is the outcome of a command for a single vehicle
+type FleetResult struct {
+	Vehicle *Vehicle
+	Err     error
+	Elapsed time.Duration
+}
+
+// FleetResults are the outcomes of a command for every vehicle in a fleet, in the fleet's order
+type FleetResults []FleetResult
+
+// FleetError is returned when a command fails for some or all of a fleet's vehicles
+type FleetError struct {
+	Failed FleetResults
+	Total  int
+}
+
+// NewFleet returns a fleet of the supplied vehicles using the default concurrency
+func NewFleet(vehicles ...*Vehicle) *Fleet {
+	return &Fleet{
+		Vehicles:    vehicles,
+		Concurrency: DefaultFleetConcurrency,
+		WakeTimeout: time.Minute,
+	}
+}
+
+// Fleet returns a fleet of every vehicle in the account
+func (v Vehicles) Fleet() *Fleet {
+	return NewFleet(v...)
+}
+
+// Run sends the command to every vehicle, returning once all have finished or the context is
+// canceled. Vehicles that haven't started when the context is canceled fail with its error
+func (f Fleet) Run(ctx context.Context, command func(ctx context.Context, v Vehicle) error) FleetResults {
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFleetConcurrency
+	}
+	results := make(FleetResults, len(f.Vehicles))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, vehicle := range f.Vehicles {
+		results[i].Vehicle = vehicle
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(result *FleetResult) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			start := time.Now()
+			result.Err = f.run(ctx, *result.Vehicle, command)
+			result.Elapsed = time.Since(start)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// run wakes the vehicle, if required, and sends it the command
+func (f Fleet) run(ctx context.Context, v Vehicle, command func(ctx context.Context, v Vehicle) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if f.WakeUp && v.State != "online" {
+		_, err := v.WakeupAndWait(ctx, f.WakeTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	return command(ctx, v)
+}
+
+// Succeeded returns the results of the vehicles the command succeeded for
+func (r FleetResults) Succeeded() FleetResults {
+	var succeeded FleetResults
+	for _, result := range r {
+		if result.Err == nil {
+			succeeded = append(succeeded, result)
+		}
+	}
+	return succeeded
+}
+
+// Failed returns the results of the vehicles the command failed for
+func (r FleetResults) Failed() FleetResults {
+	var failed FleetResults
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns a *FleetError if the command failed for any vehicle, or nil if it succeeded for all
+func (r FleetResults) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &FleetError{Failed: failed, Total: len(r)}
+}
+
+// Error lists the vehicles the command failed for, and why
+func (e *FleetError) Error() string {
+	reasons := make([]string, len(e.Failed))
+	for i, result := range e.Failed {
+		name := result.Vehicle.DisplayName
+		if name == "" {
+			name = strconv.FormatInt(result.Vehicle.ID, 10)
+		}
+		reasons[i] = name + ": " + result.Err.Error()
+	}
+	return "command failed for " + strconv.Itoa(len(e.Failed)) + " of " + strconv.Itoa(e.Total) + " vehicles: " + strings.Join(reasons, "; ")
+}
+
+// WakeupAndWait wakes up the vehicle and waits for it to come online, polling every WakePollInterval.
+// ErrWakeTimeout is returned if it isn't online before the timeout, or the context's error if the
+// context is done first
+func (v Vehicle) WakeupAndWait(ctx context.Context, timeout time.Duration) (vehicle *Vehicle, err error) {
+	ctx, span := v.startSpan(ctx, "tesla.wakeup")
+	attempts := 0
+	defer func() {
+		v.observeWakeup(ctx, span, attempts, err)
+		span.End()
+	}()
+	wakeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		attempts++
+		vehicle, err = v.wakeup(wakeCtx)
+		if err != nil && wakeCtx.Err() != nil {
+			return nil, wakeError(ctx, wakeCtx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if vehicle.State == "online" {
+			return vehicle, nil
+		}
+		select {
+		case <-time.After(WakePollInterval):
+		case <-wakeCtx.Done():
+			return nil, wakeError(ctx, wakeCtx)
+		}
+	}
+}
+
+// wakeError returns the parent context's error if it is done, or ErrWakeTimeout if the wake
+// timeout expired
+func wakeError(ctx, wakeCtx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if wakeCtx.Err() == context.DeadlineExceeded {
+		return ErrWakeTimeout
+	}
+	return wakeCtx.Err()
+}
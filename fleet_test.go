@@ -0,0 +1,115 @@
+package tesla
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFleetSpec(t *testing.T) {
+	_, client := serveAPI(t)
+	previousWakePollInterval := WakePollInterval
+	WakePollInterval = 10 * time.Millisecond
+
+	Convey("Should build a fleet from the account's vehicles", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		fleet := vehicles.Fleet()
+		So(fleet.Vehicles, ShouldHaveLength, 1)
+		So(fleet.Concurrency, ShouldEqual, DefaultFleetConcurrency)
+		results := fleet.Run(context.Background(), func(ctx context.Context, v Vehicle) error {
+			return v.LockDoors()
+		})
+		So(results.Err(), ShouldBeNil)
+		So(results.Succeeded(), ShouldHaveLength, 1)
+	})
+
+	Convey("Should bound the number of vehicles commanded at once", t, func() {
+		vehicles := make([]*Vehicle, 12)
+		for i := range vehicles {
+			vehicles[i] = &Vehicle{ID: 1234, State: "online"}
+		}
+		fleet := NewFleet(vehicles...)
+		fleet.Concurrency = 3
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+		results := fleet.Run(context.Background(), func(ctx context.Context, v Vehicle) error {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return v.SetChargeLimit(50)
+		})
+		So(results.Err(), ShouldBeNil)
+		So(results, ShouldHaveLength, 12)
+		So(maxRunning, ShouldEqual, 3)
+	})
+
+	Convey("Should report partial failures per vehicle", t, func() {
+		fleet := NewFleet(&Vehicle{ID: 1234, DisplayName: "Macak"}, &Vehicle{ID: 9999, DisplayName: "Ghost"})
+		results := fleet.Run(context.Background(), func(ctx context.Context, v Vehicle) error {
+			return v.FlashLights()
+		})
+		So(results[0].Err, ShouldBeNil)
+		So(results[1].Err.Error(), ShouldEqual, "404 Not Found")
+		So(results.Failed(), ShouldHaveLength, 1)
+		So(results.Succeeded()[0].Vehicle.DisplayName, ShouldEqual, "Macak")
+		err, ok := results.Err().(*FleetError)
+		So(ok, ShouldBeTrue)
+		So(err.Total, ShouldEqual, 2)
+		So(err.Error(), ShouldEqual, "command failed for 1 of 2 vehicles: Ghost: 404 Not Found")
+	})
+
+	Convey("Should wake vehicles before commanding them", t, func() {
+		fleet := NewFleet(&Vehicle{ID: 1234, State: "asleep"}, &Vehicle{ID: 2468, State: "asleep"}, &Vehicle{ID: 9999, State: "online"})
+		fleet.WakeUp = true
+		fleet.WakeTimeout = 50 * time.Millisecond
+		var mu sync.Mutex
+		commanded := []int64{}
+		results := fleet.Run(context.Background(), func(ctx context.Context, v Vehicle) error {
+			mu.Lock()
+			commanded = append(commanded, v.ID)
+			mu.Unlock()
+			return nil
+		})
+		So(results[0].Err, ShouldBeNil)
+		So(results[1].Err, ShouldEqual, ErrWakeTimeout)
+		So(results[2].Err, ShouldBeNil)
+		So(commanded, ShouldHaveLength, 2)
+		So(commanded, ShouldNotContain, int64(2468))
+	})
+
+	Convey("Should time out when the timeout expires during the wake-up request", t, func() {
+		_, err := Vehicle{ID: 1357}.WakeupAndWait(context.Background(), 10*time.Millisecond)
+		So(err, ShouldEqual, ErrWakeTimeout)
+	})
+
+	Convey("Should return the context's error when its deadline passes before the wake timeout", t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		_, err := Vehicle{ID: 2468}.WakeupAndWait(ctx, time.Minute)
+		So(err, ShouldResemble, context.DeadlineExceeded)
+	})
+
+	Convey("Should not command vehicles once the context is canceled", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fleet := NewFleet(&Vehicle{ID: 1234}, &Vehicle{ID: 1234})
+		results := fleet.Run(ctx, func(ctx context.Context, v Vehicle) error {
+			return v.LockDoors()
+		})
+		So(results.Failed(), ShouldHaveLength, 2)
+		So(results[0].Err, ShouldEqual, context.Canceled)
+	})
+
+	WakePollInterval = previousWakePollInterval
+}
@@ -0,0 +1,94 @@
+package tesla
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RoundTrip sends a request to the Tesla API and returns its response
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Interceptor is called with every request the client sends, after its headers are set. It may
+// modify the request, call next to continue the chain, then inspect or replace the response.
+// Returning without calling next short-circuits the request
+type Interceptor func(req *http.Request, next RoundTrip) (*http.Response, error)
+
+// Use appends interceptors to the client's chain. Interceptors run in the order they were added
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.Interceptors = append(c.Interceptors, interceptors...)
+}
+
+// ErrNilResponse is returned when an interceptor returns neither a response nor an error
+var ErrNilResponse = errors.New("interceptor returned no response")
+
+// roundTrip sends the request through the interceptor chain and then the HTTP client
+func (c Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTrip(c.HTTP.Do)
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := c.Interceptors[i], next
+		next = requireResponse(func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, inner)
+		})
+	}
+	return next(req)
+}
+
+// requireResponse turns a nil response without an error into ErrNilResponse, so outer
+// interceptors and the client never see a nil response on success
+func requireResponse(next RoundTrip) RoundTrip {
+	return func(req *http.Request) (*http.Response, error) {
+		res, err := next(req)
+		if res == nil && err == nil {
+			return nil, ErrNilResponse
+		}
+		return res, err
+	}
+}
+
+// LoggingInterceptor logs every request with its method, redacted URL, status and duration.
+// Failed requests are logged at error level. A nil logger uses slog.Default
+func LoggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		logger := logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		start := time.Now()
+		res, err := next(req)
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", Redact(req.URL.String())),
+			slog.Duration("duration", time.Since(start)),
+		}
+		level := slog.LevelInfo
+		switch {
+		case err != nil:
+			level = slog.LevelError
+			attrs = append(attrs, slog.String("error", Redact(err.Error())))
+		default:
+			attrs = append(attrs, slog.Int("status", res.StatusCode))
+			if res.StatusCode != http.StatusOK {
+				level = slog.LevelError
+			}
+		}
+		logger.LogAttrs(req.Context(), level, "tesla api request", attrs...)
+		return res, err
+	}
+}
+
+// TimingInterceptor calls observe with the duration of every request, and its status code,
+// or 0 if the request failed
+func TimingInterceptor(observe func(req *http.Request, status int, elapsed time.Duration)) Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		start := time.Now()
+		res, err := next(req)
+		status := 0
+		if err == nil {
+			status = res.StatusCode
+		}
+		observe(req, status, time.Since(start))
+		return res, err
+	}
+}
@@ -0,0 +1,115 @@
+package tesla
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInterceptorSpec(t *testing.T) {
+	ts, client := serveAPI(t)
+
+	Convey("Should run interceptors in the order they were added", t, func() {
+		client.Interceptors = nil
+		calls := []string{}
+		record := func(name string) Interceptor {
+			return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+				calls = append(calls, name+" request")
+				res, err := next(req)
+				calls = append(calls, name+" response")
+				return res, err
+			}
+		}
+		client.Use(record("outer"), record("inner"))
+		_, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		So(calls, ShouldResemble, []string{"outer request", "inner request", "inner response", "outer response"})
+	})
+
+	Convey("Should let interceptors inject headers after the client's own", t, func() {
+		client.Interceptors = nil
+		var authorization, traceID string
+		client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			req.Header.Set("X-Trace-Id", "abc")
+			return next(req)
+		}, func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			traceID = req.Header.Get("X-Trace-Id")
+			return next(req)
+		})
+		_, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		So(authorization, ShouldEqual, "Bearer ghi789")
+		So(traceID, ShouldEqual, "abc")
+	})
+
+	Convey("Should let interceptors inject faults", t, func() {
+		client.Interceptors = nil
+		sent := false
+		client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			return nil, errors.New("injected fault")
+		}, func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			sent = true
+			return next(req)
+		})
+		vehicle := Vehicle{ID: 1234}
+		err := vehicle.HonkHorn()
+		So(err.Error(), ShouldEqual, "injected fault")
+		So(sent, ShouldBeFalse)
+	})
+
+	Convey("Should fail requests an interceptor answers with no response", t, func() {
+		client.Interceptors = nil
+		buf := &bytes.Buffer{}
+		client.Use(LoggingInterceptor(slog.New(slog.NewJSONHandler(buf, nil))), func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			return nil, nil
+		})
+		_, err := client.Vehicles()
+		So(err, ShouldEqual, ErrNilResponse)
+		So(buf.String(), ShouldContainSubstring, ErrNilResponse.Error())
+	})
+
+	Convey("Should log requests with slog", t, func() {
+		client.Interceptors = nil
+		buf := &bytes.Buffer{}
+		client.Use(LoggingInterceptor(slog.New(slog.NewJSONHandler(buf, nil))))
+		_, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		err = Vehicle{ID: 9999}.FlashLights()
+		So(err, ShouldNotBeNil)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		So(lines, ShouldHaveLength, 2)
+		entry := map[string]interface{}{}
+		So(json.Unmarshal([]byte(lines[0]), &entry), ShouldBeNil)
+		So(entry["level"], ShouldEqual, "INFO")
+		So(entry["method"], ShouldEqual, "GET")
+		So(entry["url"], ShouldEqual, ts.URL+"/api/1/vehicles")
+		So(entry["status"], ShouldEqual, 200)
+		So(json.Unmarshal([]byte(lines[1]), &entry), ShouldBeNil)
+		So(entry["level"], ShouldEqual, "ERROR")
+		So(entry["status"], ShouldEqual, 404)
+	})
+
+	Convey("Should time requests", t, func() {
+		client.Interceptors = nil
+		var status int
+		var elapsed time.Duration
+		client.Use(TimingInterceptor(func(req *http.Request, s int, e time.Duration) {
+			status, elapsed = s, e
+		}))
+		_, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		So(status, ShouldEqual, 200)
+		So(elapsed, ShouldBeGreaterThan, 0)
+	})
+
+	client.Interceptors = nil
+}
@@ -0,0 +1,94 @@
+// Command gencommands generates the Vehicle methods for simple commands, and the command
+// reference, from the tesla package's command registry. Run it with go generate from the
+// repository root
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/rdbell/tesla"
+)
+
+func main() {
+	source, err := generateMethods(tesla.CommandRegistry)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile("commands_gen.go", source, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile("COMMANDS.md", generateReference(tesla.CommandRegistry), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generateMethods renders a Vehicle method for every generated command
+func generateMethods(registry []tesla.CommandSpec) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by gencommands. DO NOT EDIT.\n\n")
+	buf.WriteString("package tesla\n\nimport \"strconv\"\n")
+	for _, command := range registry {
+		if !command.Generated() {
+			continue
+		}
+		fmt.Fprintf(buf, "\n// %s %s\n", command.Method, command.Description)
+		fmt.Fprintf(buf, "func (v Vehicle) %s() error {\n", command.Method)
+		fmt.Fprintf(buf, "\tapiURL := BaseURL + \"/vehicles/\" + strconv.FormatInt(v.ID, 10) + \"/command/%s\"\n", command.Endpoint)
+		buf.WriteString("\t_, err := v.sendCommand(apiURL, nil)\n\treturn err\n}\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+// generateReference renders a markdown table describing every command
+func generateReference(registry []tesla.CommandSpec) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("<!-- Code generated by gencommands. DO NOT EDIT. -->\n\n")
+	buf.WriteString("# Commands\n\n")
+	buf.WriteString("| Method | Endpoint | Description | Payload | Requires | Moves vehicle | Idempotent |\n")
+	buf.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, command := range registry {
+		capabilities := make([]string, len(command.Capabilities))
+		for i, capability := range command.Capabilities {
+			capabilities[i] = "`" + string(capability) + "`"
+		}
+		fmt.Fprintf(buf, "| `%s` | `%s` | %s | %s | %s | %s | %s |\n",
+			command.Method, command.Endpoint, command.Description, payloadFields(command.Payload),
+			strings.Join(capabilities, ", "), yesNo(command.MovesVehicle), yesNo(command.Idempotent))
+	}
+	return buf.Bytes()
+}
+
+// payloadFields lists the JSON fields of a payload
+func payloadFields(payload interface{}) string {
+	if payload == nil {
+		return ""
+	}
+	t := reflect.TypeOf(payload)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, "`"+name+"`")
+	}
+	return strings.Join(fields, ", ")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
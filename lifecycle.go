@@ -0,0 +1,136 @@
+package tesla
+
+import (
+	"sync"
+	"time"
+)
+
+// LifecycleState is a high-level state of the vehicle, derived from its vehicle data
+type LifecycleState string
+
+// Lifecycle states. Suspended is set by a Poller that has stopped requesting vehicle data so
+// the vehicle can fall asleep, and can't be derived from the vehicle data alone
+const (
+	LifecycleAsleep    LifecycleState = "asleep"
+	LifecycleOffline   LifecycleState = "offline"
+	LifecycleOnline    LifecycleState = "online"
+	LifecycleDriving   LifecycleState = "driving"
+	LifecycleCharging  LifecycleState = "charging"
+	LifecycleUpdating  LifecycleState = "updating"
+	LifecycleSuspended LifecycleState = "suspended"
+)
+
+// Transition is a change of the vehicle's lifecycle state. Duration is how long the vehicle
+// was in the previous state, or zero if the previous state's start is unknown
+type Transition struct {
+	From     LifecycleState `json:"from"`
+	To       LifecycleState `json:"to"`
+	At       time.Time      `json:"at"`
+	Duration time.Duration  `json:"duration"`
+}
+
+// Lifecycle tracks the vehicle's lifecycle state and emits its transitions
+type Lifecycle struct {
+	mu       sync.Mutex
+	state    LifecycleState
+	since    time.Time
+	handlers []func(Transition)
+}
+
+// LifecycleStateOf derives the vehicle's lifecycle state from its vehicle data. A software
+// update being installed takes precedence over driving, and driving over charging. The state
+// of nil data is unknown, and empty
+func LifecycleStateOf(data *VehicleData) LifecycleState {
+	if data == nil {
+		return ""
+	}
+	switch data.State {
+	case "asleep":
+		return LifecycleAsleep
+	case "online", "":
+	default:
+		return LifecycleOffline
+	}
+	if data.VehicleState != nil && data.VehicleState.SoftwareUpdate.Status == "installing" {
+		return LifecycleUpdating
+	}
+	if data.DriveState != nil {
+		switch data.DriveState.ShiftState {
+		case ShiftDrive, ShiftReverse, ShiftNeutral:
+			return LifecycleDriving
+		}
+	}
+	if data.ChargeState != nil {
+		switch data.ChargeState.ChargingState {
+		case ChargingCharging, ChargingStarting:
+			return LifecycleCharging
+		}
+	}
+	return LifecycleOnline
+}
+
+// NewLifecycle returns a lifecycle whose state is unknown until it is first updated
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// State returns the current lifecycle state and when the vehicle entered it
+func (l *Lifecycle) State() (LifecycleState, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state, l.since
+}
+
+// OnTransition registers a handler called with each transition
+func (l *Lifecycle) OnTransition(handler func(Transition)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handlers = append(l.handlers, handler)
+}
+
+// Update derives the lifecycle state from the vehicle data, as of the supplied time. The
+// transition is returned if the state changed, or nil if it didn't. Nil data doesn't change it
+func (l *Lifecycle) Update(data *VehicleData, at time.Time) *Transition {
+	if data == nil {
+		return nil
+	}
+	return l.Set(LifecycleStateOf(data), at)
+}
+
+// Observe updates the lifecycle state from a Poller snapshot. Failed polls don't change it
+func (l *Lifecycle) Observe(snapshot *Snapshot) *Transition {
+	switch {
+	case snapshot.Err != nil:
+		return nil
+	case snapshot.Mode == PollSuspended:
+		return l.Set(LifecycleSuspended, snapshot.Time)
+	case snapshot.Data != nil:
+		return l.Update(snapshot.Data, snapshot.Time)
+	case snapshot.State == "asleep":
+		return l.Set(LifecycleAsleep, snapshot.Time)
+	}
+	return l.Set(LifecycleOffline, snapshot.Time)
+}
+
+// Set moves the lifecycle to the supplied state, as of the supplied time, and emits the
+// transition. The transition is returned if the state changed, or nil if it didn't
+func (l *Lifecycle) Set(state LifecycleState, at time.Time) *Transition {
+	l.mu.Lock()
+	if state == l.state {
+		l.mu.Unlock()
+		return nil
+	}
+	transition := Transition{From: l.state, To: state, At: at}
+	if !l.since.IsZero() {
+		transition.Duration = at.Sub(l.since)
+	}
+	l.state = state
+	l.since = at
+	handlers := make([]func(Transition), len(l.handlers))
+	copy(handlers, l.handlers)
+	l.mu.Unlock()
+	for _, handler := range handlers {
+		handler(transition)
+	}
+	return &transition
+}
@@ -0,0 +1,78 @@
+package tesla
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLifecycleSpec(t *testing.T) {
+	Convey("Should derive the lifecycle state from the vehicle data", t, func() {
+		So(LifecycleStateOf(&VehicleData{Vehicle: Vehicle{State: "asleep"}}), ShouldEqual, LifecycleAsleep)
+		So(LifecycleStateOf(&VehicleData{Vehicle: Vehicle{State: "offline"}}), ShouldEqual, LifecycleOffline)
+		So(LifecycleStateOf(&VehicleData{Vehicle: Vehicle{State: "online"}}), ShouldEqual, LifecycleOnline)
+		So(LifecycleStateOf(&VehicleData{
+			Vehicle:     Vehicle{State: "online"},
+			DriveState:  &DriveState{ShiftState: ShiftReverse},
+			ChargeState: &ChargeState{ChargingState: ChargingCharging},
+		}), ShouldEqual, LifecycleDriving)
+		So(LifecycleStateOf(&VehicleData{
+			Vehicle:     Vehicle{State: "online"},
+			DriveState:  &DriveState{ShiftState: ShiftPark},
+			ChargeState: &ChargeState{ChargingState: ChargingCharging},
+		}), ShouldEqual, LifecycleCharging)
+		updating := &VehicleState{}
+		updating.SoftwareUpdate.Status = "installing"
+		So(LifecycleStateOf(&VehicleData{Vehicle: Vehicle{State: "online"}, VehicleState: updating}), ShouldEqual, LifecycleUpdating)
+	})
+
+	Convey("Should leave the state unchanged without vehicle data", t, func() {
+		So(LifecycleStateOf(nil), ShouldEqual, LifecycleState(""))
+		lifecycle := NewLifecycle()
+		lifecycle.Set(LifecycleOnline, time.Now())
+		So(lifecycle.Update(nil, time.Now()), ShouldBeNil)
+		state, _ := lifecycle.State()
+		So(state, ShouldEqual, LifecycleOnline)
+	})
+
+	Convey("Should emit transitions with their durations", t, func() {
+		lifecycle := NewLifecycle()
+		transitions := []Transition{}
+		lifecycle.OnTransition(func(transition Transition) {
+			transitions = append(transitions, transition)
+		})
+		start := time.Unix(1543186971, 0)
+
+		transition := lifecycle.Update(&VehicleData{Vehicle: Vehicle{State: "online"}}, start)
+		So(transition.From, ShouldEqual, LifecycleState(""))
+		So(transition.To, ShouldEqual, LifecycleOnline)
+		So(transition.Duration, ShouldEqual, 0)
+
+		So(lifecycle.Update(&VehicleData{Vehicle: Vehicle{State: "online"}}, start.Add(time.Minute)), ShouldBeNil)
+
+		driving := &VehicleData{Vehicle: Vehicle{State: "online"}, DriveState: &DriveState{ShiftState: ShiftDrive}}
+		transition = lifecycle.Update(driving, start.Add(5*time.Minute))
+		So(transition.From, ShouldEqual, LifecycleOnline)
+		So(transition.To, ShouldEqual, LifecycleDriving)
+		So(transition.Duration, ShouldEqual, 5*time.Minute)
+
+		state, since := lifecycle.State()
+		So(state, ShouldEqual, LifecycleDriving)
+		So(since, ShouldEqual, start.Add(5*time.Minute))
+		So(len(transitions), ShouldEqual, 2)
+	})
+
+	Convey("Should follow the poller's snapshots", t, func() {
+		lifecycle := NewLifecycle()
+		now := time.Now()
+		lifecycle.Observe(&Snapshot{Time: now, Mode: PollOnline, State: "online", Data: &VehicleData{Vehicle: Vehicle{State: "online"}}})
+		transition := lifecycle.Observe(&Snapshot{Time: now.Add(time.Minute), Mode: PollSuspended, State: "online"})
+		So(transition.To, ShouldEqual, LifecycleSuspended)
+		So(lifecycle.Observe(&Snapshot{Time: now.Add(2 * time.Minute), Err: ErrVehicleNotFound}), ShouldBeNil)
+		transition = lifecycle.Observe(&Snapshot{Time: now.Add(3 * time.Minute), Mode: PollAsleep, State: "asleep"})
+		So(transition.From, ShouldEqual, LifecycleSuspended)
+		So(transition.To, ShouldEqual, LifecycleAsleep)
+		So(transition.Duration, ShouldEqual, 2*time.Minute)
+	})
+}
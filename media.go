@@ -0,0 +1,77 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// MaxVolume is the loudest volume accepted by AdjustVolume
+const MaxVolume = 11.0
+
+var (
+	// ErrMediaRemoteControlDisabled is returned when the vehicle doesn't currently allow remote media control
+	ErrMediaRemoteControlDisabled = errors.New("media remote control is not enabled")
+	// ErrVolumeOutOfRange is returned when a volume is outside of 0-MaxVolume
+	ErrVolumeOutOfRange = errors.New("volume out of range")
+)
+
+// MediaTogglePlayback toggles between playing and pausing the current media. Like every media
+// command, it fetches the vehicle state first to check that remote media control is enabled
+func (v Vehicle) MediaTogglePlayback() error {
+	return v.media("media_toggle_playback", nil)
+}
+
+// MediaNextTrack skips to the next track
+func (v Vehicle) MediaNextTrack() error {
+	return v.media("media_next_track", nil)
+}
+
+// MediaPrevTrack skips to the previous track
+func (v Vehicle) MediaPrevTrack() error {
+	return v.media("media_prev_track", nil)
+}
+
+// MediaNextFavorite skips to the next saved favorite
+func (v Vehicle) MediaNextFavorite() error {
+	return v.media("media_next_fav", nil)
+}
+
+// MediaPrevFavorite skips to the previous saved favorite
+func (v Vehicle) MediaPrevFavorite() error {
+	return v.media("media_prev_fav", nil)
+}
+
+// MediaVolumeUp turns up the volume of the media
+func (v Vehicle) MediaVolumeUp() error {
+	return v.media("media_volume_up", nil)
+}
+
+// MediaVolumeDown turns down the volume of the media
+func (v Vehicle) MediaVolumeDown() error {
+	return v.media("media_volume_down", nil)
+}
+
+// AdjustVolume sets the volume of the media, from 0 to MaxVolume
+func (v Vehicle) AdjustVolume(volume float64) error {
+	if volume < 0 || volume > MaxVolume {
+		return ErrVolumeOutOfRange
+	}
+	body, _ := json.Marshal(volumePayload{volume})
+	return v.media("adjust_volume", body)
+}
+
+// media fetches the vehicle state and sends a media command, provided the vehicle has media
+// remote control enabled
+func (v Vehicle) media(command string, body []byte) error {
+	vehicleState, err := v.VehicleState()
+	if err != nil {
+		return err
+	}
+	if !vehicleState.MediaState.RemoteControlEnabled {
+		return ErrMediaRemoteControlDisabled
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/" + command
+	_, err = v.sendCommand(apiURL, body)
+	return err
+}
@@ -0,0 +1,43 @@
+package tesla
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	MediaDisabledVehicleStateJSON = `{"response":{"locked":true,"media_state":{"remote_control_enabled":false},"vehicle_name":"Macak"}}`
+)
+
+func TestMediaSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should control the media", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.MediaTogglePlayback(), ShouldBeNil)
+		So(vehicle.MediaNextTrack(), ShouldBeNil)
+		So(vehicle.MediaPrevTrack(), ShouldBeNil)
+		So(vehicle.MediaNextFavorite(), ShouldBeNil)
+		So(vehicle.MediaPrevFavorite(), ShouldBeNil)
+		So(vehicle.MediaVolumeUp(), ShouldBeNil)
+		So(vehicle.MediaVolumeDown(), ShouldBeNil)
+	})
+
+	Convey("Should adjust the volume", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.AdjustVolume(5.5), ShouldBeNil)
+		So(vehicle.AdjustVolume(12), ShouldEqual, ErrVolumeOutOfRange)
+		So(vehicle.AdjustVolume(-1), ShouldEqual, ErrVolumeOutOfRange)
+	})
+
+	Convey("Should not send media commands while remote control is disabled", t, func() {
+		vehicle := Vehicle{ID: 3579}
+		So(vehicle.MediaTogglePlayback(), ShouldEqual, ErrMediaRemoteControlDisabled)
+		So(vehicle.AdjustVolume(5), ShouldEqual, ErrMediaRemoteControlDisabled)
+	})
+}
@@ -0,0 +1,53 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// NavigationLocale is the locale sent with shared navigation requests
+var NavigationLocale = "en-US"
+
+// ErrNavigationUnsupported is returned when the vehicle can't accept navigation requests
+var ErrNavigationUnsupported = errors.New("vehicle cannot accept navigation requests")
+
+// Navigate shares an address with the vehicle, which starts navigating to it. Each navigation
+// command fetches the vehicle config first to check that the vehicle can accept the request
+func (v Vehicle) Navigate(address string) error {
+	shareRequest := sharePayload{
+		Type:        "share_ext_content_raw",
+		Locale:      NavigationLocale,
+		TimestampMs: strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10),
+	}
+	shareRequest.Value.Text = address
+	body, _ := json.Marshal(shareRequest)
+	return v.navigate("navigation_request", body)
+}
+
+// NavigateToLocation starts navigating to the supplied latitude and longitude
+func (v Vehicle) NavigateToLocation(lat, lon float64) error {
+	body, _ := json.Marshal(gpsPayload{lat, lon, 0})
+	return v.navigate("navigation_gps_request", body)
+}
+
+// NavigateToSupercharger starts navigating to the Supercharger with the supplied site ID
+func (v Vehicle) NavigateToSupercharger(siteID int) error {
+	body, _ := json.Marshal(superchargerPayload{siteID, 0})
+	return v.navigate("navigation_sc_request", body)
+}
+
+// navigate sends a navigation command, provided the vehicle can accept navigation requests
+func (v Vehicle) navigate(command string, body []byte) error {
+	vehicleConfig, err := v.VehicleConfig()
+	if err != nil {
+		return err
+	}
+	if !vehicleConfig.CanAcceptNavigationRequests {
+		return ErrNavigationUnsupported
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/" + command
+	_, err = v.sendCommand(apiURL, body)
+	return err
+}
@@ -0,0 +1,46 @@
+package tesla
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	NoNavigationVehicleConfigJSON = `{"response":{"can_accept_navigation_requests":false,"car_type":"s"}}`
+)
+
+func TestNavigationSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should share an address with the car", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.Navigate("3500 Deer Creek Road, Palo Alto, CA")
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should navigate to a location", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.NavigateToLocation(37.4, -122.1)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should navigate to a Supercharger", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.NavigateToSupercharger(42)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should not navigate vehicles that can't accept navigation requests", t, func() {
+		vehicle := Vehicle{ID: 3579}
+		So(vehicle.Navigate("Tesla HQ"), ShouldEqual, ErrNavigationUnsupported)
+		So(vehicle.NavigateToLocation(35.1, 20.2), ShouldEqual, ErrNavigationUnsupported)
+		So(vehicle.NavigateToSupercharger(42), ShouldEqual, ErrNavigationUnsupported)
+	})
+}
@@ -0,0 +1,90 @@
+package tesla
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	NullChargeStateJSON    = `{"charger_phases":null,"managed_charging_start_time":null,"scheduled_charging_start_time":null,"charge_port_cold_weather_mode":null}`
+	NumberChargeStateJSON  = `{"charger_phases":3,"managed_charging_start_time":1618729200,"scheduled_charging_start_time":1618729200000,"charge_port_cold_weather_mode":true}`
+	MissingChargeStateJSON = `{"charging_state":"Complete"}`
+	NullDriveStateJSON     = `{"speed":null}`
+	NumberDriveStateJSON   = `{"speed":65.5}`
+	NullVehicleJSON        = `{"color":null,"backseat_token":null,"backseat_token_updated_at":null}`
+	SetVehicleJSON         = `{"color":"red","backseat_token":"abc","backseat_token_updated_at":1618729200}`
+	FractionalPhasesJSON   = `{"charger_phases":1.5}`
+)
+
+func TestNullableSpec(t *testing.T) {
+	Convey("Should leave null charge state fields unset", t, func() {
+		chargeState := &ChargeState{}
+		So(json.Unmarshal([]byte(NullChargeStateJSON), chargeState), ShouldBeNil)
+		So(chargeState.ChargerPhases, ShouldBeNil)
+		So(chargeState.ManagedChargingStartTime, ShouldBeNil)
+		So(chargeState.ManagedChargingStartAt().IsZero(), ShouldBeTrue)
+		So(chargeState.ScheduledChargingStartTime, ShouldBeNil)
+		So(chargeState.ChargePortColdWeatherMode, ShouldBeNil)
+		_, ok := chargeState.ScheduledChargingStart()
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Should parse numeric charge state fields", t, func() {
+		chargeState := &ChargeState{}
+		So(json.Unmarshal([]byte(NumberChargeStateJSON), chargeState), ShouldBeNil)
+		So(*chargeState.ChargerPhases, ShouldEqual, 3)
+		So(chargeState.ManagedChargingStartAt().Equal(time.Unix(1618729200, 0)), ShouldBeTrue)
+		start, ok := chargeState.ScheduledChargingStart()
+		So(ok, ShouldBeTrue)
+		So(start.Unix(), ShouldEqual, 1618729200)
+		So(*chargeState.ChargePortColdWeatherMode, ShouldBeTrue)
+	})
+
+	Convey("Should leave missing charge state fields unset", t, func() {
+		chargeState := &ChargeState{}
+		So(json.Unmarshal([]byte(MissingChargeStateJSON), chargeState), ShouldBeNil)
+		So(chargeState.ChargerPhases, ShouldBeNil)
+		So(chargeState.ScheduledChargingStartTime, ShouldBeNil)
+	})
+
+	Convey("Should reject fractional integers", t, func() {
+		So(json.Unmarshal([]byte(FractionalPhasesJSON), &ChargeState{}), ShouldNotBeNil)
+	})
+
+	Convey("Should parse the drive state speed", t, func() {
+		driveState := &DriveState{}
+		So(json.Unmarshal([]byte(NullDriveStateJSON), driveState), ShouldBeNil)
+		So(driveState.Speed, ShouldBeNil)
+		So(json.Unmarshal([]byte(NumberDriveStateJSON), driveState), ShouldBeNil)
+		So(*driveState.Speed, ShouldEqual, 65.5)
+	})
+
+	Convey("Should parse the vehicle's nullable fields", t, func() {
+		vehicle := &Vehicle{}
+		So(json.Unmarshal([]byte(NullVehicleJSON), vehicle), ShouldBeNil)
+		So(vehicle.Color, ShouldBeNil)
+		So(vehicle.BackseatToken, ShouldBeNil)
+		So(vehicle.BackseatTokenUpdatedAt, ShouldBeNil)
+		So(vehicle.BackseatTokenUpdated().IsZero(), ShouldBeTrue)
+		So(json.Unmarshal([]byte(SetVehicleJSON), vehicle), ShouldBeNil)
+		So(*vehicle.Color, ShouldEqual, "red")
+		So(*vehicle.BackseatToken, ShouldEqual, "abc")
+		So(vehicle.BackseatTokenUpdated().Unix(), ShouldEqual, 1618729200)
+	})
+
+	Convey("Should encode unset fields as null and set ones as the API sent them", t, func() {
+		chargeState := &ChargeState{}
+		So(json.Unmarshal([]byte(NumberChargeStateJSON), chargeState), ShouldBeNil)
+		body, err := json.Marshal(struct {
+			Phases   *int64 `json:"charger_phases"`
+			Start    *int64 `json:"scheduled_charging_start_time"`
+			Managed  *int64 `json:"managed_charging_start_time"`
+			Nullable *int64 `json:"nullable"`
+		}{chargeState.ChargerPhases, chargeState.ScheduledChargingStartTime, chargeState.ManagedChargingStartTime, nil})
+		So(err, ShouldBeNil)
+		So(string(body), ShouldEqual, `{"charger_phases":3,"scheduled_charging_start_time":1618729200000,"managed_charging_start_time":1618729200,"nullable":null}`)
+	})
+}
@@ -0,0 +1,223 @@
+package tesla
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PollMode is what the poller believes the vehicle is doing, which sets how often it polls
+type PollMode string
+
+// Poll modes. While suspended, the poller stops requesting vehicle data so that the vehicle can
+// fall asleep, and only watches its state in the vehicles list
+const (
+	PollDriving   PollMode = "driving"
+	PollCharging  PollMode = "charging"
+	PollOnline    PollMode = "online"
+	PollSuspended PollMode = "suspended"
+	PollAsleep    PollMode = "asleep"
+	PollOffline   PollMode = "offline"
+)
+
+// Snapshot is a single poll of the vehicle. Data is only set when the vehicle was online and not
+// suspended, so it is nil while the vehicle sleeps. Err holds the error if the poll failed
+type Snapshot struct {
+	Time  time.Time
+	Mode  PollMode
+	State string
+	Data  *VehicleData
+	Err   error
+}
+
+// Poller polls a vehicle as often as its activity warrants, without waking it. The vehicle's
+// state is read from the vehicles list, which doesn't wake the vehicle, and vehicle data is only
+// requested while it is online. Once the vehicle has been idle for IdleTimeout, the poller
+// suspends vehicle data requests so the vehicle can fall asleep, resuming them when the vehicle
+// has slept or after SuspendTimeout. Mode and Interval may be called while the poller runs, but
+// Poll must not be called concurrently with itself or Start
+type Poller struct {
+	Vehicle *Vehicle
+	// DrivingInterval and ChargingInterval are how often to poll while driving or charging
+	DrivingInterval  time.Duration
+	ChargingInterval time.Duration
+	// OnlineInterval is how often to poll an idle vehicle, doubling up to MaxInterval while it stays idle
+	OnlineInterval time.Duration
+	// AsleepInterval is how often to check the state of a sleeping, offline or suspended vehicle,
+	// doubling up to MaxInterval while its state doesn't change
+	AsleepInterval time.Duration
+	MaxInterval    time.Duration
+	IdleTimeout    time.Duration
+	SuspendTimeout time.Duration
+	// Options are passed to VehicleDataWith, and default to LetSleep
+	Options []VehicleDataOption
+
+	mu          sync.Mutex
+	mode        PollMode
+	interval    time.Duration
+	idleSince   time.Time
+	suspendedAt time.Time
+}
+
+// NewPoller returns a poller for the vehicle with intervals suited to the Tesla API
+func NewPoller(v *Vehicle) *Poller {
+	return &Poller{
+		Vehicle:          v,
+		DrivingInterval:  15 * time.Second,
+		ChargingInterval: 30 * time.Second,
+		OnlineInterval:   time.Minute,
+		AsleepInterval:   time.Minute,
+		MaxInterval:      10 * time.Minute,
+		IdleTimeout:      15 * time.Minute,
+		SuspendTimeout:   21 * time.Minute,
+		Options:          []VehicleDataOption{LetSleep},
+	}
+}
+
+// Start polls the vehicle until the context is done, publishing each snapshot on the returned
+// channel, which is closed once polling stops. Polling waits for each snapshot to be received
+func (p *Poller) Start(ctx context.Context) <-chan *Snapshot {
+	snapshots := make(chan *Snapshot)
+	go func() {
+		defer close(snapshots)
+		for {
+			snapshot := p.Poll(ctx)
+			select {
+			case snapshots <- snapshot:
+			case <-ctx.Done():
+				return
+			}
+			timer := time.NewTimer(p.Interval())
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return snapshots
+}
+
+// Poll polls the vehicle once, and sets the interval until the next poll. Start calls it in a
+// loop; call it directly, instead of Start, to drive the poller from your own scheduler.
+// Canceling the context cancels the poll's requests
+func (p *Poller) Poll(ctx context.Context) *Snapshot {
+	now := time.Now()
+	mode := p.Mode()
+	snapshot := &Snapshot{Time: now, Mode: mode}
+	state, err := p.vehicleState(ctx)
+	if err != nil {
+		snapshot.Err = err
+		return p.publish(snapshot, mode)
+	}
+	snapshot.State = state
+
+	p.mu.Lock()
+	suspended := mode == PollSuspended && now.Sub(p.suspendedAt) < p.SuspendTimeout
+	p.mu.Unlock()
+	switch {
+	case state == "asleep":
+		snapshot.Mode = PollAsleep
+	case state != "online":
+		snapshot.Mode = PollOffline
+	case suspended:
+		snapshot.Mode = PollSuspended
+	default:
+		options := p.Options
+		if options == nil {
+			options = []VehicleDataOption{LetSleep}
+		}
+		data, err := p.Vehicle.vehicleDataWith(ctx, options...)
+		if err != nil {
+			snapshot.Mode = PollOnline
+			snapshot.Err = err
+			break
+		}
+		snapshot.Data = data
+		snapshot.Mode = pollMode(data)
+		p.mu.Lock()
+		if snapshot.Mode != PollOnline || mode != PollOnline {
+			p.idleSince = now
+		} else if now.Sub(p.idleSince) >= p.IdleTimeout {
+			snapshot.Mode = PollSuspended
+			p.suspendedAt = now
+		}
+		p.mu.Unlock()
+	}
+	return p.publish(snapshot, snapshot.Mode)
+}
+
+// Mode returns the poller's current mode
+func (p *Poller) Mode() PollMode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.mode
+}
+
+// Interval returns the time until the next poll
+func (p *Poller) Interval() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.interval
+}
+
+// Sets the interval for the mode, then records the mode of the snapshot
+func (p *Poller) publish(snapshot *Snapshot, intervalMode PollMode) *Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.setInterval(intervalMode)
+	p.mode = snapshot.Mode
+	return snapshot
+}
+
+// Sets the interval until the next poll, backing off while the mode is unchanged and the
+// vehicle is idle, asleep or suspended. The caller holds p.mu
+func (p *Poller) setInterval(mode PollMode) {
+	var base time.Duration
+	switch mode {
+	case PollDriving:
+		p.interval = p.DrivingInterval
+		return
+	case PollCharging:
+		p.interval = p.ChargingInterval
+		return
+	case PollOnline:
+		base = p.OnlineInterval
+	default:
+		base = p.AsleepInterval
+	}
+	if mode != p.mode || p.interval < base {
+		p.interval = base
+		return
+	}
+	p.interval *= 2
+	if p.MaxInterval > 0 && p.interval > p.MaxInterval {
+		p.interval = p.MaxInterval
+	}
+}
+
+// Looks up the vehicle's state in the vehicles list, which doesn't wake the vehicle
+func (p *Poller) vehicleState(ctx context.Context) (string, error) {
+	vehicles, err := p.Vehicle.apiClient().vehicles(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, vehicle := range vehicles {
+		if vehicle.ID == p.Vehicle.ID {
+			return vehicle.State, nil
+		}
+	}
+	return "", ErrVehicleNotFound
+}
+
+// Determines what the vehicle is doing from its data
+func pollMode(data *VehicleData) PollMode {
+	switch LifecycleStateOf(data) {
+	case LifecycleDriving:
+		return PollDriving
+	case LifecycleCharging:
+		return PollCharging
+	}
+	return PollOnline
+}
@@ -0,0 +1,96 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPollerSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should determine the poll mode from the vehicle data", t, func() {
+		So(pollMode(&VehicleData{}), ShouldEqual, PollOnline)
+		So(pollMode(&VehicleData{DriveState: &DriveState{ShiftState: ShiftDrive}}), ShouldEqual, PollDriving)
+		So(pollMode(&VehicleData{DriveState: &DriveState{ShiftState: ShiftPark}}), ShouldEqual, PollOnline)
+		So(pollMode(&VehicleData{ChargeState: &ChargeState{ChargingState: ChargingCharging}}), ShouldEqual, PollCharging)
+	})
+
+	Convey("Should back off and suspend polling while the vehicle is idle", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		poller := NewPoller(vehicles[0])
+		poller.IdleTimeout = 0
+
+		snapshot := poller.Poll(context.Background())
+		So(snapshot.Err, ShouldBeNil)
+		So(snapshot.State, ShouldEqual, "online")
+		So(snapshot.Mode, ShouldEqual, PollOnline)
+		So(snapshot.Data.ChargeState.BatteryLevel, ShouldEqual, 80)
+		So(poller.Interval(), ShouldEqual, time.Minute)
+
+		snapshot = poller.Poll(context.Background())
+		So(snapshot.Mode, ShouldEqual, PollSuspended)
+		So(poller.Interval(), ShouldEqual, time.Minute)
+
+		snapshot = poller.Poll(context.Background())
+		So(snapshot.Mode, ShouldEqual, PollSuspended)
+		So(snapshot.Data, ShouldBeNil)
+		So(poller.Interval(), ShouldEqual, 2*time.Minute)
+
+		poller.SuspendTimeout = 0
+		snapshot = poller.Poll(context.Background())
+		So(snapshot.Mode, ShouldEqual, PollOnline)
+		So(snapshot.Data, ShouldNotBeNil)
+		So(poller.Interval(), ShouldEqual, time.Minute)
+	})
+
+	Convey("Should back off up to the maximum interval", t, func() {
+		poller := NewPoller(&Vehicle{ID: 1234})
+		poller.MaxInterval = 3 * time.Minute
+		for i := 0; i < 4; i++ {
+			poller.setInterval(PollAsleep)
+			poller.mode = PollAsleep
+		}
+		So(poller.Interval(), ShouldEqual, 3*time.Minute)
+		poller.setInterval(PollDriving)
+		So(poller.Interval(), ShouldEqual, 15*time.Second)
+	})
+
+	Convey("Should return an error for vehicles that aren't on the account", t, func() {
+		poller := NewPoller(&Vehicle{ID: 9999})
+		snapshot := poller.Poll(context.Background())
+		So(snapshot.Err, ShouldEqual, ErrVehicleNotFound)
+		So(snapshot.Data, ShouldBeNil)
+	})
+
+	Convey("Should cancel the poll's requests with the context", t, func() {
+		poller := NewPoller(&Vehicle{ID: 1234})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		snapshot := poller.Poll(ctx)
+		So(errors.Is(snapshot.Err, context.Canceled), ShouldBeTrue)
+		So(snapshot.Data, ShouldBeNil)
+	})
+
+	Convey("Should publish snapshots until the context is done", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		poller := NewPoller(vehicles[0])
+		poller.OnlineInterval = time.Millisecond
+		ctx, cancel := context.WithCancel(context.Background())
+		snapshots := poller.Start(ctx)
+		first := <-snapshots
+		So(poller.Mode(), ShouldEqual, PollOnline)
+		So(poller.Interval(), ShouldBeGreaterThan, 0)
+		second := <-snapshots
+		So(first.Mode, ShouldEqual, PollOnline)
+		So(second.Time.After(first.Time), ShouldBeTrue)
+		cancel()
+		for range snapshots {
+		}
+	})
+}
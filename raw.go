@@ -0,0 +1,95 @@
+package tesla
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Do calls an arbitrary Tesla API endpoint, for endpoints this library doesn't yet support.
+// The path is relative to BaseURL unless it is a full URL. The in value is sent as the JSON
+// request body, or as-is if it is a []byte, and the JSON response is decoded into out.
+// Either may be nil. The request is sent once, through the client's interceptors, and isn't retried
+func (c Client) Do(ctx context.Context, method, path string, in, out interface{}) error {
+	apiURL := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		apiURL = BaseURL + path
+	}
+	reqBody, err := marshalBody(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return err
+	}
+	body, err := c.processRequest(req)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// Command sends an arbitrary command to the vehicle, for commands this library doesn't yet
+// support. The payload is sent as the JSON request body and may be nil. If the vehicle rejects
+// the command, the response is returned along with an error holding the reason. The command is
+// sent once and an asleep vehicle isn't woken; use CommandAwake for that
+func (v Vehicle) Command(ctx context.Context, name string, payload interface{}) (*CommandResponse, error) {
+	reqBody, err := marshalBody(payload)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := v.startCommandSpan(ctx, name)
+	defer span.End()
+	response := &CommandResponse{}
+	start := time.Now()
+	err = v.apiClient().Do(ctx, "POST", "/vehicles/"+strconv.FormatInt(v.ID, 10)+"/command/"+name, reqBody, response)
+	if err != nil {
+		response = nil
+	}
+	v.audit(name, reqBody, start, response, err)
+	v.observeCommand(ctx, span, name, start, response, err)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Response.Result && response.Response.Reason != "" {
+		return response, errors.New(response.Response.Reason)
+	}
+	return response, nil
+}
+
+// CommandAwake sends an arbitrary command like Command, but if the vehicle is asleep or otherwise
+// unavailable, and the API responds with a 408, it wakes the vehicle with WakeupAndWait and sends
+// the command once more. The wake timeout bounds how long to wait for the vehicle to come online
+func (v Vehicle) CommandAwake(ctx context.Context, name string, payload interface{}, wakeTimeout time.Duration) (*CommandResponse, error) {
+	response, err := v.Command(ctx, name, payload)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusRequestTimeout {
+		return response, err
+	}
+	_, err = v.WakeupAndWait(ctx, wakeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return v.Command(ctx, name, payload)
+}
+
+// marshalBody encodes a request body as JSON, unless it is already a []byte
+func marshalBody(in interface{}) ([]byte, error) {
+	switch body := in.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return body, nil
+	default:
+		return json.Marshal(body)
+	}
+}
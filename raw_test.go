@@ -0,0 +1,102 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRawSpec(t *testing.T) {
+	_, client := serveAPI(t)
+	previousWakePollInterval := WakePollInterval
+	WakePollInterval = 10 * time.Millisecond
+	// asleep is how many more commands the vehicle answers with a 408, as it does while asleep
+	asleep := 0
+	wakeups := 0
+	client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/wake_up") {
+			wakeups++
+		}
+		if asleep > 0 && strings.Contains(req.URL.Path, "/command/") {
+			asleep--
+			return &http.Response{StatusCode: 408, Status: "408 Request Timeout", Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}
+		return next(req)
+	})
+
+	Convey("Should call an arbitrary endpoint", t, func() {
+		releaseNotes := &struct {
+			Response struct {
+				ReleaseNotes []struct {
+					Title string `json:"title"`
+				} `json:"release_notes"`
+			} `json:"response"`
+		}{}
+		err := client.Do(context.Background(), "GET", "/vehicles/1234/release_notes", nil, releaseNotes)
+		So(err, ShouldBeNil)
+		So(releaseNotes.Response.ReleaseNotes[0].Title, ShouldEqual, "Boombox")
+	})
+
+	Convey("Should return HTTP errors from arbitrary endpoints", t, func() {
+		err := client.Do(context.Background(), "GET", "/vehicles/1234/missing", nil, nil)
+		So(err.Error(), ShouldEqual, "404 Not Found")
+	})
+
+	Convey("Should honor the context", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := client.Do(ctx, "GET", "/vehicles/1234/release_notes", nil, nil)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Should send an arbitrary command", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		response, err := vehicle.Command(context.Background(), "remote_boombox", map[string]int{"sound": 2000})
+		So(err, ShouldBeNil)
+		So(response.Response.Result, ShouldBeTrue)
+	})
+
+	Convey("Should return the reason an arbitrary command was rejected", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		response, err := vehicle.Command(context.Background(), "remote_auto_steering_wheel_heat_climate_request", nil)
+		So(err.Error(), ShouldEqual, "not_supported")
+		So(response.Response.Result, ShouldBeFalse)
+	})
+	Convey("Should neither wake nor retry by default", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		asleep, wakeups = 1, 0
+		_, err = vehicles[0].Command(context.Background(), "remote_boombox", map[string]int{"sound": 2000})
+		var statusErr *StatusError
+		So(errors.As(err, &statusErr), ShouldBeTrue)
+		So(statusErr.StatusCode, ShouldEqual, 408)
+		So(wakeups, ShouldEqual, 0)
+	})
+
+	Convey("Should wake an unavailable vehicle and resend the command", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		asleep, wakeups = 1, 0
+		response, err := vehicles[0].CommandAwake(context.Background(), "remote_boombox", map[string]int{"sound": 2000}, time.Second)
+		So(err, ShouldBeNil)
+		So(response.Response.Result, ShouldBeTrue)
+		So(wakeups, ShouldEqual, 1)
+
+		asleep, wakeups = 2, 0
+		_, err = vehicles[0].CommandAwake(context.Background(), "remote_boombox", map[string]int{"sound": 2000}, time.Second)
+		So(err, ShouldNotBeNil)
+		So(wakeups, ShouldEqual, 1)
+	})
+
+	WakePollInterval = previousWakePollInterval
+}
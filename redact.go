@@ -0,0 +1,67 @@
+package tesla
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces secret values scrubbed by Redact
+const Redacted = "REDACTED"
+
+// SensitiveFields are the query parameters and JSON fields whose values are secrets
+var SensitiveFields = []string{"password", "access_token", "refresh_token", "client_secret", "token", "pin"}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"]+`)
+
+	// The query and body patterns for SensitiveFields, recompiled only when the fields change
+	sensitiveMu    sync.Mutex
+	sensitiveNames string
+	sensitiveQuery *regexp.Regexp
+	sensitiveBody  *regexp.Regexp
+)
+
+// sensitivePatterns returns the query and body patterns matching the current SensitiveFields
+func sensitivePatterns() (*regexp.Regexp, *regexp.Regexp) {
+	fields := make([]string, len(SensitiveFields))
+	for i, field := range SensitiveFields {
+		fields[i] = regexp.QuoteMeta(field)
+	}
+	names := strings.Join(fields, "|")
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+	if sensitiveQuery == nil || names != sensitiveNames {
+		sensitiveNames = names
+		sensitiveQuery = regexp.MustCompile(`(?i)([?&](?:` + names + `)=)[^&\s"]*`)
+		sensitiveBody = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	}
+	return sensitiveQuery, sensitiveBody
+}
+
+// Redact scrubs secrets from a URL, error message or JSON document: the values of any
+// SensitiveFields query parameters or JSON string fields, and bearer tokens
+func Redact(s string) string {
+	query, body := sensitivePatterns()
+	s = query.ReplaceAllString(s, "${1}"+Redacted)
+	s = body.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+	return bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// RedactJSON scrubs secrets from a JSON document, such as a recorded response fixture
+func RedactJSON(body []byte) []byte {
+	return []byte(Redact(string(body)))
+}
+
+// redactError scrubs secrets from an error returned by the HTTP client, which includes the request URL
+func redactError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: Redact(urlErr.URL),
+			Err: urlErr.Err,
+		}
+	}
+	return err
+}
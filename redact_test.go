@@ -0,0 +1,48 @@
+package tesla
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedactSpec(t *testing.T) {
+	Convey("Should redact secrets from URLs", t, func() {
+		redacted := Redact("https://owner-api.teslamotors.com/api/1/vehicles/1/command/remote_start_drive?password=hunter2&foo=bar")
+		So(redacted, ShouldEqual, "https://owner-api.teslamotors.com/api/1/vehicles/1/command/remote_start_drive?password=REDACTED&foo=bar")
+	})
+
+	Convey("Should redact secrets from JSON", t, func() {
+		redacted := RedactJSON([]byte(`{"email":"elon@tesla.com","password": "hun\"ter2","access_token":"abc","expires_in":3600}`))
+		So(string(redacted), ShouldEqual, `{"email":"elon@tesla.com","password": "REDACTED","access_token":"REDACTED","expires_in":3600}`)
+	})
+
+	Convey("Should pick up changes to the sensitive fields", t, func() {
+		previousFields := SensitiveFields
+		defer func() { SensitiveFields = previousFields }()
+		So(Redact(`{"vin":"5YJ3E1EA1JF000001"}`), ShouldEqual, `{"vin":"5YJ3E1EA1JF000001"}`)
+		SensitiveFields = append([]string{"vin"}, previousFields...)
+		So(Redact(`{"vin":"5YJ3E1EA1JF000001"}`), ShouldEqual, `{"vin":"REDACTED"}`)
+	})
+
+	Convey("Should redact bearer tokens", t, func() {
+		So(Redact("Authorization: Bearer ghi789"), ShouldEqual, "Authorization: Bearer REDACTED")
+	})
+
+	Convey("Should redact the URL of HTTP client errors", t, func() {
+		err := redactError(&url.Error{Op: "Post", URL: "https://example.com/?password=hunter2", Err: errors.New("connection refused")})
+		So(strings.Contains(err.Error(), "hunter2"), ShouldBeFalse)
+		So(err.Error(), ShouldContainSubstring, "connection refused")
+	})
+
+	Convey("Should not leak secrets from failed requests", t, func() {
+		client := &Client{HTTP: &http.Client{}}
+		_, err := client.get("http://127.0.0.1:0/?access_token=ghi789")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldNotContainSubstring, "ghi789")
+	})
+}
@@ -0,0 +1,203 @@
+package tesla
+
+import "encoding/json"
+
+//go:generate go run ./internal/gencommands
+
+// Capability is a vehicle feature, or caller opt-in, that a command requires
+type Capability string
+
+// Capabilities required by commands
+const (
+	CapabilityMotion             Capability = "motion"
+	CapabilityMediaRemoteControl Capability = "media_remote_control"
+	CapabilityNavigation         Capability = "navigation"
+)
+
+// CommandSpec describes a vehicle command. Payload is an example of the JSON request body, with
+// placeholder values, or nil if the command takes none. Fields such as the vehicle's location are
+// filled in by its Vehicle method. Commands without a payload, capabilities or motion have their
+// Vehicle methods generated into commands_gen.go; the rest are written by hand
+type CommandSpec struct {
+	Method       string
+	Endpoint     string
+	Description  string
+	Payload      interface{}
+	Capabilities []Capability
+	MovesVehicle bool
+	Idempotent   bool
+}
+
+// Generated indicates whether the command's Vehicle method is generated from the registry
+func (c CommandSpec) Generated() bool {
+	return c.Payload == nil && len(c.Capabilities) == 0 && !c.MovesVehicle
+}
+
+// LookupCommand finds a command in the registry by its method name or endpoint
+func LookupCommand(name string) (CommandSpec, bool) {
+	for _, command := range CommandRegistry {
+		if command.Method == name || command.Endpoint == name {
+			return command, true
+		}
+	}
+	return CommandSpec{}, false
+}
+
+type windowPayload struct {
+	Command string  `json:"command"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+type percentPayload struct {
+	Percent int `json:"percent"`
+}
+
+// temperaturePayload has always sent the driver temperature as a string
+type temperaturePayload struct {
+	DriverTemp    string      `json:"driver_temp"`
+	PassengerTemp json.Number `json:"passenger_temp"`
+}
+
+type sunRoofPayload struct {
+	State   string `json:"state"`
+	Percent int    `json:"percent"`
+}
+
+type passwordPayload struct {
+	Password string `json:"password"`
+}
+
+type trunkPayload struct {
+	WhichTrunk string `json:"which_trunk"`
+}
+
+// switchPayload turns a feature on or off. The flag has always been sent as a string
+type switchPayload struct {
+	On string `json:"on"`
+}
+
+type seatHeaterPayload struct {
+	Heater int `json:"heater"`
+	Level  int `json:"level"`
+}
+
+type softwareUpdatePayload struct {
+	OffsetSec int64 `json:"offset_sec"`
+}
+
+type scheduledChargingPayload struct {
+	Enable bool      `json:"enable"`
+	Time   TimeOfDay `json:"time"`
+}
+
+type scheduledDeparturePayload struct {
+	Enable                      bool      `json:"enable"`
+	DepartureTime               TimeOfDay `json:"departure_time"`
+	PreconditioningEnabled      bool      `json:"preconditioning_enabled"`
+	PreconditioningWeekdaysOnly bool      `json:"preconditioning_weekdays_only"`
+	OffPeakChargingEnabled      bool      `json:"off_peak_charging_enabled"`
+	OffPeakChargingWeekdaysOnly bool      `json:"off_peak_charging_weekdays_only"`
+	EndOffPeakTime              TimeOfDay `json:"end_off_peak_time"`
+}
+
+type chargingAmpsPayload struct {
+	ChargingAmps int `json:"charging_amps"`
+}
+
+type volumePayload struct {
+	Volume float64 `json:"volume"`
+}
+
+type sharePayload struct {
+	Type  string `json:"type"`
+	Value struct {
+		Text string `json:"android.intent.extra.TEXT"`
+	} `json:"value"`
+	Locale      string `json:"locale"`
+	TimestampMs string `json:"timestamp_ms"`
+}
+
+type gpsPayload struct {
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+	Order int     `json:"order"`
+}
+
+type superchargerPayload struct {
+	ID    int `json:"id"`
+	Order int `json:"order"`
+}
+
+type pinPayload struct {
+	PIN string `json:"pin"`
+}
+
+type speedLimitPayload struct {
+	LimitMph int `json:"limit_mph"`
+}
+
+type valetPayload struct {
+	On       bool   `json:"on"`
+	Password string `json:"password,omitempty"`
+}
+
+// sharePayloadExample returns an example navigation share payload
+func sharePayloadExample() sharePayload {
+	share := sharePayload{Type: "share_ext_content_raw", Locale: "en-US", TimestampMs: "0"}
+	share.Value.Text = "<address>"
+	return share
+}
+
+// CommandRegistry describes every vehicle command supported by this library
+var CommandRegistry = []CommandSpec{
+	{Method: "AutoparkForward", Endpoint: "autopark_request", Description: "commands the vehicle to pull forward", Payload: &AutoParkRequest{VehicleID: 1, Action: "start_forward"}, Capabilities: []Capability{CapabilityMotion}, MovesVehicle: true},
+	{Method: "AutoparkReverse", Endpoint: "autopark_request", Description: "commands the vehicle to go in reverse", Payload: &AutoParkRequest{VehicleID: 1, Action: "start_reverse"}, Capabilities: []Capability{CapabilityMotion}, MovesVehicle: true},
+	{Method: "AutoparkAbort", Endpoint: "autopark_request", Description: "aborts an autopark request", Payload: &AutoParkRequest{VehicleID: 1, Action: "abort"}, Idempotent: true},
+	{Method: "TriggerHomelink", Endpoint: "trigger_homelink", Description: "opens and closes the configured Homelink garage door of the vehicle", Payload: &AutoParkRequest{}},
+	{Method: "OpenChargePort", Endpoint: "charge_port_door_open", Description: "opens the vehicle's charge port", Idempotent: true},
+	{Method: "CloseChargePort", Endpoint: "charge_port_door_close", Description: "closes the vehicle's charge port", Idempotent: true},
+	{Method: "ResetValetPIN", Endpoint: "reset_valet_pin", Description: "resets the valet mode PIN, if set", Idempotent: true},
+	{Method: "SetChargeLimitStandard", Endpoint: "charge_standard", Description: "sets the charge limit to the default setting", Idempotent: true},
+	{Method: "SetChargeLimitMax", Endpoint: "charge_max_range", Description: "sets the charge limit to the maximum value", Idempotent: true},
+	{Method: "SetChargeLimit", Endpoint: "set_charge_limit", Description: "sets the charge limit to a supplied percent value", Payload: percentPayload{80}, Idempotent: true},
+	{Method: "StartCharging", Endpoint: "charge_start", Description: "starts the charging of the vehicle if charging cable is inserted", Idempotent: true},
+	{Method: "StopCharging", Endpoint: "charge_stop", Description: "stops a vehicle's charge session", Idempotent: true},
+	{Method: "FlashLights", Endpoint: "flash_lights", Description: "flashes the lights of the vehicle"},
+	{Method: "HonkHorn", Endpoint: "honk_horn", Description: "honks the vehicle's horn"},
+	{Method: "UnlockDoors", Endpoint: "door_unlock", Description: "unlocks the vehicle's doors", Idempotent: true},
+	{Method: "LockDoors", Endpoint: "door_lock", Description: "locks the vehicle's doors", Idempotent: true},
+	{Method: "SetTemperature", Endpoint: "set_temps", Description: "sets the driver and passenger temperatures of the vehicle", Payload: temperaturePayload{"21", "21"}, Idempotent: true},
+	{Method: "StartAirConditioning", Endpoint: "auto_conditioning_start", Description: "starts the vehicle's air conditioner", Idempotent: true},
+	{Method: "StopAirConditioning", Endpoint: "auto_conditioning_stop", Description: "stops the vehicle's air conditioner", Idempotent: true},
+	{Method: "MovePanoRoof", Endpoint: "sun_roof_control", Description: "controls the state of the panoramic roof", Payload: sunRoofPayload{"vent", 15}, Idempotent: true},
+	{Method: "Start", Endpoint: "remote_start_drive", Description: "starts the car, allowing it to be driven without a key", Payload: passwordPayload{"<password>"}, Capabilities: []Capability{CapabilityMotion}, Idempotent: true},
+	{Method: "OpenTrunk", Endpoint: "trunk_open", Description: "opens the front or rear trunk", Payload: trunkPayload{"rear"}},
+	{Method: "VentWindows", Endpoint: "window_control", Description: "vents the vehicle's windows", Payload: windowPayload{"vent", 0, 0}, Idempotent: true},
+	{Method: "CloseWindows", Endpoint: "window_control", Description: "closes the vehicle's windows", Payload: windowPayload{"close", 0, 0}, Idempotent: true},
+	{Method: "SetSentryMode", Endpoint: "set_sentry_mode", Description: "controls Sentry Mode's active state", Payload: switchPayload{"true"}, Idempotent: true},
+	{Method: "HeatSeat", Endpoint: "remote_seat_heater_request", Description: "sets heating for a seat", Payload: seatHeaterPayload{0, 3}, Idempotent: true},
+	{Method: "HeatWheel", Endpoint: "remote_steering_wheel_heater_request", Description: "turns steering wheel heat on or off", Payload: switchPayload{"true"}, Idempotent: true},
+	{Method: "ScheduleSoftwareUpdate", Endpoint: "schedule_software_update", Description: "schedules the installation of the available software update", Payload: softwareUpdatePayload{0}, Idempotent: true},
+	{Method: "CancelSoftwareUpdate", Endpoint: "cancel_software_update", Description: "cancels a previously-scheduled software update that has not yet started", Idempotent: true},
+	{Method: "SetScheduledCharging", Endpoint: "set_scheduled_charging", Description: "enables or disables scheduled charging", Payload: scheduledChargingPayload{true, 1380}, Idempotent: true},
+	{Method: "SetScheduledDeparture", Endpoint: "set_scheduled_departure", Description: "enables or disables scheduled departure", Payload: scheduledDeparturePayload{true, 420, true, false, true, false, 360}, Idempotent: true},
+	{Method: "SetChargingAmps", Endpoint: "set_charging_amps", Description: "sets the charging current", Payload: chargingAmpsPayload{16}, Idempotent: true},
+	{Method: "MediaTogglePlayback", Endpoint: "media_toggle_playback", Description: "toggles between playing and pausing the current media", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaNextTrack", Endpoint: "media_next_track", Description: "skips to the next track", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaPrevTrack", Endpoint: "media_prev_track", Description: "skips to the previous track", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaNextFavorite", Endpoint: "media_next_fav", Description: "skips to the next saved favorite", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaPrevFavorite", Endpoint: "media_prev_fav", Description: "skips to the previous saved favorite", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaVolumeUp", Endpoint: "media_volume_up", Description: "turns up the volume of the media", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "MediaVolumeDown", Endpoint: "media_volume_down", Description: "turns down the volume of the media", Capabilities: []Capability{CapabilityMediaRemoteControl}},
+	{Method: "AdjustVolume", Endpoint: "adjust_volume", Description: "sets the volume of the media", Payload: volumePayload{5}, Capabilities: []Capability{CapabilityMediaRemoteControl}, Idempotent: true},
+	{Method: "Navigate", Endpoint: "navigation_request", Description: "shares an address with the vehicle, which starts navigating to it", Payload: sharePayloadExample(), Capabilities: []Capability{CapabilityNavigation}, Idempotent: true},
+	{Method: "NavigateToLocation", Endpoint: "navigation_gps_request", Description: "starts navigating to a latitude and longitude", Payload: gpsPayload{0, 0, 0}, Capabilities: []Capability{CapabilityNavigation}, Idempotent: true},
+	{Method: "NavigateToSupercharger", Endpoint: "navigation_sc_request", Description: "starts navigating to a Supercharger", Payload: superchargerPayload{0, 0}, Capabilities: []Capability{CapabilityNavigation}, Idempotent: true},
+	{Method: "ActivateSpeedLimit", Endpoint: "speed_limit_activate", Description: "turns on speed limit mode", Payload: pinPayload{"0000"}, Idempotent: true},
+	{Method: "DeactivateSpeedLimit", Endpoint: "speed_limit_deactivate", Description: "turns off speed limit mode", Payload: pinPayload{"0000"}, Idempotent: true},
+	{Method: "ClearSpeedLimitPIN", Endpoint: "speed_limit_clear_pin", Description: "clears the speed limit mode PIN", Payload: pinPayload{"0000"}, Idempotent: true},
+	{Method: "SetSpeedLimit", Endpoint: "speed_limit_set_limit", Description: "sets the maximum speed in mph", Payload: speedLimitPayload{70}, Idempotent: true},
+	{Method: "EnableValetMode", Endpoint: "set_valet_mode", Description: "turns on valet mode", Payload: valetPayload{true, "0000"}, Idempotent: true},
+	{Method: "DisableValetMode", Endpoint: "set_valet_mode", Description: "turns off valet mode", Payload: valetPayload{false, ""}, Idempotent: true},
+}
@@ -0,0 +1,226 @@
+package tesla
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// sentCommand is a command request recorded by the registry test
+type sentCommand struct {
+	Endpoint string
+	Body     string
+}
+
+// payloadFields returns the top-level JSON fields of a request body or example payload
+func payloadFields(payload interface{}) []string {
+	var raw []byte
+	switch p := payload.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = []byte(p)
+	default:
+		raw, _ = json.Marshal(p)
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	decoded := map[string]json.RawMessage{}
+	json.Unmarshal(raw, &decoded)
+	fields := []string{}
+	for field := range decoded {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestRegistrySpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	var mu sync.Mutex
+	sent := []sentCommand{}
+	client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		if i := strings.Index(req.URL.Path, "/command/"); i >= 0 {
+			body := []byte{}
+			if req.Body != nil {
+				body, _ = io.ReadAll(req.Body)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+			}
+			mu.Lock()
+			sent = append(sent, sentCommand{req.URL.Path[i+len("/command/"):], string(body)})
+			mu.Unlock()
+		}
+		return next(req)
+	})
+	vehicles, _ := client.Vehicles()
+	vehicle := vehicles[0]
+	parked := &Vehicle{ID: 5678, VehicleID: 456}
+	capability, _ := GrantMotionCapability(MotionAcknowledgement)
+	policy := &SafetyPolicy{MaxDriveStateAge: 100 * 365 * 24 * time.Hour}
+
+	// Calls every registry method with the mock server's fixture values, and the body it should send.
+	// An empty body means none is sent
+	calls := map[string]struct {
+		call func() error
+		body string
+	}{
+		"AutoparkForward": {func() error {
+			maneuver, err := parked.AutoparkForward(context.Background(), capability, policy)
+			if err != nil {
+				return err
+			}
+			return maneuver.Stop()
+		}, `{"vehicle_id":456,"lat":37.4,"lon":-122.1,"action":"start_forward"}`},
+		"AutoparkReverse": {func() error {
+			maneuver, err := parked.AutoparkReverse(context.Background(), capability, policy)
+			if err != nil {
+				return err
+			}
+			return maneuver.Stop()
+		}, `{"vehicle_id":456,"lat":37.4,"lon":-122.1,"action":"start_reverse"}`},
+		"AutoparkAbort":          {vehicle.AutoparkAbort, `{"vehicle_id":456,"lat":35.1,"lon":20.2,"action":"abort"}`},
+		"TriggerHomelink":        {vehicle.TriggerHomelink, `{"lat":35.1,"lon":20.2}`},
+		"OpenChargePort":         {vehicle.OpenChargePort, ``},
+		"CloseChargePort":        {vehicle.CloseChargePort, ``},
+		"ResetValetPIN":          {vehicle.ResetValetPIN, ``},
+		"SetChargeLimitStandard": {vehicle.SetChargeLimitStandard, ``},
+		"SetChargeLimitMax":      {vehicle.SetChargeLimitMax, ``},
+		"SetChargeLimit":         {func() error { return vehicle.SetChargeLimit(50) }, `{"percent":50}`},
+		"StartCharging":          {vehicle.StartCharging, ``},
+		"StopCharging":           {vehicle.StopCharging, ``},
+		"FlashLights":            {vehicle.FlashLights, ``},
+		"HonkHorn":               {vehicle.HonkHorn, ``},
+		"UnlockDoors":            {vehicle.UnlockDoors, ``},
+		"LockDoors":              {vehicle.LockDoors, ``},
+		"SetTemperature":         {func() error { return vehicle.SetTemperature(21.5, 20) }, `{"driver_temp":"21.5","passenger_temp":20}`},
+		"StartAirConditioning":   {vehicle.StartAirConditioning, ``},
+		"StopAirConditioning":    {vehicle.StopAirConditioning, ``},
+		"MovePanoRoof":           {func() error { return vehicle.MovePanoRoof("move", 50) }, `{"state":"move","percent":50}`},
+		"Start":                  {func() error { return parked.Start(capability, "foo") }, `{"password":"foo"}`},
+		"OpenTrunk":              {func() error { return vehicle.OpenTrunk("rear") }, `{"which_trunk":"rear"}`},
+		"VentWindows":            {vehicle.VentWindows, `{"command":"vent","lat":35.1,"lon":20.2}`},
+		"CloseWindows":           {vehicle.CloseWindows, `{"command":"close","lat":35.1,"lon":20.2}`},
+		"SetSentryMode":          {func() error { return vehicle.SetSentryMode(true) }, `{"on":"true"}`},
+		"HeatSeat":               {func() error { return vehicle.HeatSeat(1, 3) }, `{"heater":1,"level":3}`},
+		"HeatWheel":              {func() error { return vehicle.HeatWheel(false) }, `{"on":"false"}`},
+		"ScheduleSoftwareUpdate": {func() error { return vehicle.ScheduleSoftwareUpdate(120) }, `{"offset_sec":120}`},
+		"CancelSoftwareUpdate":   {vehicle.CancelSoftwareUpdate, ``},
+		"SetScheduledCharging":   {func() error { return vehicle.SetScheduledCharging(true, 450) }, `{"enable":true,"time":450}`},
+		"SetScheduledDeparture": {func() error {
+			return vehicle.SetScheduledDeparture(ScheduledDeparture{
+				Enable:                      true,
+				DepartureTime:               480,
+				PreconditioningEnabled:      true,
+				PreconditioningWeekdaysOnly: true,
+				OffPeakChargingEnabled:      true,
+				EndOffPeakTime:              360,
+			})
+		}, `{"enable":true,"departure_time":480,"preconditioning_enabled":true,"preconditioning_weekdays_only":true,"off_peak_charging_enabled":true,"off_peak_charging_weekdays_only":false,"end_off_peak_time":360}`},
+		"SetChargingAmps":        {func() error { return vehicle.SetChargingAmps(32) }, `{"charging_amps":32}`},
+		"MediaTogglePlayback":    {vehicle.MediaTogglePlayback, ``},
+		"MediaNextTrack":         {vehicle.MediaNextTrack, ``},
+		"MediaPrevTrack":         {vehicle.MediaPrevTrack, ``},
+		"MediaNextFavorite":      {vehicle.MediaNextFavorite, ``},
+		"MediaPrevFavorite":      {vehicle.MediaPrevFavorite, ``},
+		"MediaVolumeUp":          {vehicle.MediaVolumeUp, ``},
+		"MediaVolumeDown":        {vehicle.MediaVolumeDown, ``},
+		"AdjustVolume":           {func() error { return vehicle.AdjustVolume(5.5) }, `{"volume":5.5}`},
+		"Navigate":               {func() error { return vehicle.Navigate("3500 Deer Creek Road, Palo Alto, CA") }, ``},
+		"NavigateToLocation":     {func() error { return vehicle.NavigateToLocation(37.4, -122.1) }, `{"lat":37.4,"lon":-122.1,"order":0}`},
+		"NavigateToSupercharger": {func() error { return vehicle.NavigateToSupercharger(42) }, `{"id":42,"order":0}`},
+		"ActivateSpeedLimit":     {func() error { return vehicle.ActivateSpeedLimit("1234") }, `{"pin":"1234"}`},
+		"DeactivateSpeedLimit":   {func() error { return vehicle.DeactivateSpeedLimit("1234") }, `{"pin":"1234"}`},
+		"ClearSpeedLimitPIN":     {func() error { return vehicle.ClearSpeedLimitPIN("1234") }, `{"pin":"1234"}`},
+		"SetSpeedLimit":          {func() error { return vehicle.SetSpeedLimit(65) }, `{"limit_mph":65}`},
+		"EnableValetMode":        {func() error { return vehicle.EnableValetMode("1234") }, `{"on":true,"password":"1234"}`},
+		"DisableValetMode":       {func() error { return vehicle.DisableValetMode("") }, `{"on":false}`},
+	}
+
+	// The reasons the mock server gives for rejecting commands, which are still sent
+	rejected := map[string]string{
+		"SetChargeLimitStandard": "already_standard",
+		"StartCharging":          "complete",
+	}
+
+	Convey("Every command in the registry should have a method on Vehicle values", t, func() {
+		// Generated methods have value receivers, so HonkHorn, which had a pointer receiver, can
+		// now also be called on a Vehicle value
+		vehicleType := reflect.TypeOf(Vehicle{})
+		for _, command := range CommandRegistry {
+			_, ok := vehicleType.MethodByName(command.Method)
+			So(ok, ShouldBeTrue)
+		}
+	})
+
+	Convey("Every command's method should send its registered endpoint and payload", t, func() {
+		So(len(calls), ShouldEqual, len(CommandRegistry))
+		for _, command := range CommandRegistry {
+			call, ok := calls[command.Method]
+			So(ok, ShouldBeTrue)
+			mu.Lock()
+			sent = sent[:0]
+			mu.Unlock()
+			err := call.call()
+			mu.Lock()
+			So(sent, ShouldNotBeEmpty)
+			// Commands such as HeatSeat start the climate first, so the command itself is sent last,
+			// unless it starts a maneuver which is aborted later
+			request := sent[len(sent)-1]
+			if command.MovesVehicle {
+				request = sent[0]
+			}
+			mu.Unlock()
+			if reason, ok := rejected[command.Method]; ok {
+				So(err.Error(), ShouldEqual, reason)
+			} else {
+				So(err, ShouldBeNil)
+			}
+			So(request.Endpoint, ShouldEqual, command.Endpoint)
+			So(payloadFields(request.Body), ShouldResemble, payloadFields(command.Payload))
+			if call.body != "" || command.Payload == nil {
+				So(request.Body, ShouldEqual, call.body)
+			}
+		}
+	})
+
+	Convey("Should send the navigation share payload", t, func() {
+		mu.Lock()
+		sent = sent[:0]
+		mu.Unlock()
+		So(vehicle.Navigate("3500 Deer Creek Road, Palo Alto, CA"), ShouldBeNil)
+		mu.Lock()
+		defer mu.Unlock()
+		So(sent, ShouldHaveLength, 1)
+		share := sharePayload{}
+		So(json.Unmarshal([]byte(sent[0].Body), &share), ShouldBeNil)
+		So(share.Type, ShouldEqual, "share_ext_content_raw")
+		So(share.Value.Text, ShouldEqual, "3500 Deer Creek Road, Palo Alto, CA")
+	})
+
+	Convey("Only simple commands should be generated", t, func() {
+		command, ok := LookupCommand("HonkHorn")
+		So(ok, ShouldBeTrue)
+		So(command.Generated(), ShouldBeTrue)
+		command, ok = LookupCommand("set_charge_limit")
+		So(ok, ShouldBeTrue)
+		So(command.Method, ShouldEqual, "SetChargeLimit")
+		So(command.Generated(), ShouldBeFalse)
+		command, _ = LookupCommand("AutoparkForward")
+		So(command.MovesVehicle, ShouldBeTrue)
+		So(command.Generated(), ShouldBeFalse)
+		_, ok = LookupCommand("self_destruct")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -0,0 +1,219 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// MotionAcknowledgement must be passed to GrantMotionCapability to opt in to commands that move
+// the vehicle or enable it to be driven
+const MotionAcknowledgement = "I understand this command can move the vehicle"
+
+var (
+	// ErrMotionNotAcknowledged is returned when a motion capability is requested without the MotionAcknowledgement
+	ErrMotionNotAcknowledged = errors.New("motion capability requires the motion acknowledgement")
+	// ErrMotionCapabilityRequired is returned when a command that moves the vehicle is sent without a motion capability
+	ErrMotionCapabilityRequired = errors.New("a motion capability is required for this command")
+	// ErrDriveStateStale is returned when the vehicle's drive state is too old to safely move it
+	ErrDriveStateStale = errors.New("drive state is too old to safely move the vehicle")
+	// ErrVehicleNotParked is returned when the vehicle is not in park
+	ErrVehicleNotParked = errors.New("vehicle must be in park")
+	// ErrHeartbeatMissed is returned when a maneuver is aborted because the caller stopped sending heartbeats
+	ErrHeartbeatMissed = errors.New("heartbeat missed, maneuver aborted")
+)
+
+// MotionCapability is an explicit opt-in to commands that move the vehicle or enable it to be driven
+type MotionCapability struct {
+	acknowledged bool
+}
+
+// GrantMotionCapability returns a motion capability, provided the acknowledgement is the MotionAcknowledgement
+func GrantMotionCapability(acknowledgement string) (*MotionCapability, error) {
+	if acknowledgement != MotionAcknowledgement {
+		return nil, ErrMotionNotAcknowledged
+	}
+	return &MotionCapability{acknowledged: true}, nil
+}
+
+// SafetyPolicy describes the checks made before, and while, moving the vehicle. The drive state
+// must be no older than MaxDriveStateAge, and a maneuver is aborted if the caller doesn't send a
+// heartbeat within HeartbeatTimeout. HeartbeatFrequency is how often the caller should heartbeat
+type SafetyPolicy struct {
+	MaxDriveStateAge   time.Duration
+	HeartbeatFrequency time.Duration
+	HeartbeatTimeout   time.Duration
+}
+
+// DefaultSafetyPolicy requires a drive state from the last 30 seconds, and asks for a heartbeat
+// every 500ms and aborts a maneuver after a second without one
+var DefaultSafetyPolicy = &SafetyPolicy{
+	MaxDriveStateAge:   30 * time.Second,
+	HeartbeatFrequency: 500 * time.Millisecond,
+	HeartbeatTimeout:   time.Second,
+}
+
+// SafetyPolicyFromStream returns a safety policy using the heartbeat requirements advertised by the
+// vehicle's stream. The heartbeat timeout is the autopark pause timeout, or twice the heartbeat
+// frequency if the vehicle didn't advertise one
+func SafetyPolicyFromStream(event *StreamEventResponse, maxDriveStateAge time.Duration) *SafetyPolicy {
+	policy := &SafetyPolicy{
+		MaxDriveStateAge:   maxDriveStateAge,
+		HeartbeatFrequency: time.Duration(event.Autopark.HeartbeatFrequency) * time.Millisecond,
+		HeartbeatTimeout:   time.Duration(event.Autopark.AutoparkPauseTimeout) * time.Millisecond,
+	}
+	if policy.HeartbeatTimeout == 0 {
+		policy.HeartbeatTimeout = 2 * policy.HeartbeatFrequency
+	}
+	return policy
+}
+
+// heartbeatTimeout returns the time allowed between heartbeats, falling back to the default
+// policy's timeout if the policy sets neither a timeout nor a frequency
+func (p SafetyPolicy) heartbeatTimeout() time.Duration {
+	if p.HeartbeatTimeout > 0 {
+		return p.HeartbeatTimeout
+	}
+	if p.HeartbeatFrequency > 0 {
+		return 2 * p.HeartbeatFrequency
+	}
+	return DefaultSafetyPolicy.HeartbeatTimeout
+}
+
+// checkParked fetches the drive state and verifies that both it and its GPS fix are fresh, and that
+// the vehicle is in park
+func (v Vehicle) checkParked(capability *MotionCapability, policy *SafetyPolicy) (*DriveState, error) {
+	if capability == nil || !capability.acknowledged {
+		return nil, ErrMotionCapabilityRequired
+	}
+	driveState, err := v.DriveState()
+	if err != nil {
+		return nil, err
+	}
+	if driveState.GpsStale(policy.MaxDriveStateAge) || driveState.Stale(policy.MaxDriveStateAge) {
+		return nil, ErrDriveStateStale
+	}
+	if driveState.ShiftState != ShiftPark {
+		return nil, ErrVehicleNotParked
+	}
+	return driveState, nil
+}
+
+// Maneuver is an autopark maneuver in progress. The maneuver is aborted when its context is
+// canceled, when Stop is called, or when Heartbeat isn't called within the policy's heartbeat timeout
+type Maneuver struct {
+	abortFunc  func(reason error) error
+	heartbeat  chan struct{}
+	stop       chan struct{}
+	stopReason error
+	stopOnce   sync.Once
+	done       chan struct{}
+	mu         sync.Mutex
+	err        error
+}
+
+// startManeuver checks the vehicle is safe to move, sends the autopark action and watches the maneuver
+func (v Vehicle) startManeuver(ctx context.Context, capability *MotionCapability, policy *SafetyPolicy, action string) (*Maneuver, error) {
+	// The maneuver outlives this call, so it keeps the client it was started with
+	v.client = v.apiClient()
+	if policy == nil {
+		policy = DefaultSafetyPolicy
+	}
+	driveState, err := v.checkParked(capability, policy)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	err = v.autoPark(action, driveState)
+	if err != nil {
+		return nil, err
+	}
+	return newManeuver(ctx, policy.heartbeatTimeout(), func(error) error {
+		return v.AutoparkAbort()
+	}), nil
+}
+
+// newManeuver watches a maneuver, calling abort with the reason once it must stop
+func newManeuver(ctx context.Context, timeout time.Duration, abort func(reason error) error) *Maneuver {
+	maneuver := &Maneuver{
+		abortFunc: abort,
+		heartbeat: make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go maneuver.watch(ctx, timeout)
+	return maneuver
+}
+
+// watch aborts the maneuver on cancellation, stop or a missed heartbeat
+func (m *Maneuver) watch(ctx context.Context, timeout time.Duration) {
+	defer close(m.done)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.heartbeat:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+			continue
+		case <-ctx.Done():
+			m.abort(ctx.Err())
+		case <-timer.C:
+			m.abort(ErrHeartbeatMissed)
+		case <-m.stop:
+			m.abort(m.stopReason)
+		}
+		return
+	}
+}
+
+// abort sends the abort, recording why the maneuver ended
+func (m *Maneuver) abort(reason error) {
+	err := m.abortFunc(reason)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = reason
+	if m.err == nil {
+		m.err = err
+	}
+}
+
+// halt asks the watcher to abort the maneuver for the supplied reason
+func (m *Maneuver) halt(reason error) {
+	m.stopOnce.Do(func() {
+		m.stopReason = reason
+		close(m.stop)
+	})
+}
+
+// Heartbeat tells the maneuver the caller is still present, keeping the vehicle moving
+func (m *Maneuver) Heartbeat() {
+	select {
+	case m.heartbeat <- struct{}{}:
+	default:
+	}
+}
+
+// Stop aborts the maneuver and waits for the abort to be sent
+func (m *Maneuver) Stop() error {
+	m.halt(nil)
+	<-m.done
+	return m.Err()
+}
+
+// Done returns a channel that is closed once the maneuver has been aborted
+func (m *Maneuver) Done() <-chan struct{} {
+	return m.done
+}
+
+// Err returns why the maneuver was aborted, or nil if it is still in progress or was stopped
+func (m *Maneuver) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
@@ -0,0 +1,136 @@
+package tesla
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ParkedDriveStateJSON = `{"response":{"shift_state":"P","speed":null,"latitude":37.4,"longitude":-122.1,"heading":90,"gps_as_of":%d,"timestamp":%d}}`
+)
+
+func TestSafetySpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	// driveState replaces the drive state served by the mock API while it is set
+	driveState := ""
+	client.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		if driveState != "" && strings.HasSuffix(req.URL.Path, "/data_request/drive_state") {
+			return &http.Response{StatusCode: 200, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(driveState)), Request: req}, nil
+		}
+		return next(req)
+	})
+	parked := &Vehicle{ID: 5678, VehicleID: 456}
+	policy := &SafetyPolicy{
+		MaxDriveStateAge:   time.Minute,
+		HeartbeatFrequency: 10 * time.Millisecond,
+		HeartbeatTimeout:   50 * time.Millisecond,
+	}
+
+	Convey("Should require the motion acknowledgement", t, func() {
+		_, err := GrantMotionCapability("yes")
+		So(err, ShouldEqual, ErrMotionNotAcknowledged)
+		_, err = parked.AutoparkForward(context.Background(), &MotionCapability{}, policy)
+		So(err, ShouldEqual, ErrMotionCapabilityRequired)
+	})
+
+	Convey("Should refuse to move with a stale drive state", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		_, err = vehicle.AutoparkForward(context.Background(), capability, policy)
+		So(err, ShouldEqual, ErrDriveStateStale)
+	})
+
+	Convey("Should refuse to move when only the GPS fix is fresh", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		driveState = fmt.Sprintf(ParkedDriveStateJSON, time.Now().Unix(), time.Now().Add(-time.Hour).UnixNano()/int64(time.Millisecond))
+		defer func() { driveState = "" }()
+		_, err := parked.AutoparkForward(context.Background(), capability, policy)
+		So(err, ShouldEqual, ErrDriveStateStale)
+		err = parked.Start(capability, "foo")
+		So(err, ShouldEqual, ErrDriveStateStale)
+	})
+
+	Convey("Should refuse to move when the car isn't in park", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		now := time.Now()
+		driveState = strings.Replace(fmt.Sprintf(ParkedDriveStateJSON, now.Unix(), now.UnixNano()/int64(time.Millisecond)), `"shift_state":"P"`, `"shift_state":"D"`, 1)
+		defer func() { driveState = "" }()
+		_, err := parked.AutoparkReverse(context.Background(), capability, policy)
+		So(err, ShouldEqual, ErrVehicleNotParked)
+	})
+
+	Convey("Should keep moving while heartbeats arrive", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		maneuver, err := parked.AutoparkForward(context.Background(), capability, policy)
+		So(err, ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			maneuver.Heartbeat()
+			time.Sleep(policy.HeartbeatFrequency)
+		}
+		aborted := false
+		select {
+		case <-maneuver.Done():
+			aborted = true
+		default:
+		}
+		So(aborted, ShouldBeFalse)
+		So(maneuver.Stop(), ShouldBeNil)
+	})
+
+	Convey("Should abort when heartbeats stop", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		maneuver, err := parked.AutoparkForward(context.Background(), capability, policy)
+		So(err, ShouldBeNil)
+		<-maneuver.Done()
+		So(maneuver.Err(), ShouldEqual, ErrHeartbeatMissed)
+	})
+
+	Convey("Should abort when the context is canceled", t, func() {
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		ctx, cancel := context.WithCancel(context.Background())
+		maneuver, err := parked.AutoparkReverse(ctx, capability, &SafetyPolicy{MaxDriveStateAge: time.Minute, HeartbeatTimeout: time.Minute})
+		So(err, ShouldBeNil)
+		cancel()
+		<-maneuver.Done()
+		So(maneuver.Err(), ShouldEqual, context.Canceled)
+	})
+
+	Convey("Should fall back to the default heartbeat timeout for partial policies", t, func() {
+		So(SafetyPolicy{MaxDriveStateAge: time.Minute}.heartbeatTimeout(), ShouldEqual, DefaultSafetyPolicy.HeartbeatTimeout)
+		So(SafetyPolicy{HeartbeatFrequency: time.Second}.heartbeatTimeout(), ShouldEqual, 2*time.Second)
+		So(SafetyPolicyFromStream(&StreamEventResponse{}, time.Minute).heartbeatTimeout(), ShouldEqual, DefaultSafetyPolicy.HeartbeatTimeout)
+
+		capability, _ := GrantMotionCapability(MotionAcknowledgement)
+		maneuver, err := parked.AutoparkForward(context.Background(), capability, &SafetyPolicy{MaxDriveStateAge: 100 * 365 * 24 * time.Hour})
+		So(err, ShouldBeNil)
+		aborted := false
+		select {
+		case <-maneuver.Done():
+			aborted = true
+		case <-time.After(DefaultSafetyPolicy.HeartbeatTimeout / 2):
+		}
+		So(aborted, ShouldBeFalse)
+		So(maneuver.Stop(), ShouldBeNil)
+	})
+
+	Convey("Should build a policy from the stream's autopark settings", t, func() {
+		event := &StreamEventResponse{}
+		event.Autopark.HeartbeatFrequency = 500
+		event.Autopark.AutoparkPauseTimeout = 2000
+		policy := SafetyPolicyFromStream(event, time.Minute)
+		So(policy.HeartbeatFrequency, ShouldEqual, 500*time.Millisecond)
+		So(policy.HeartbeatTimeout, ShouldEqual, 2*time.Second)
+		event.Autopark.AutoparkPauseTimeout = 0
+		So(SafetyPolicyFromStream(event, time.Minute).HeartbeatTimeout, ShouldEqual, time.Second)
+	})
+}
@@ -0,0 +1,140 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeOfDay represents a local time of day as the number of minutes after midnight,
+// which is how the Tesla API encodes scheduled charging and departure times
+type TimeOfDay int
+
+// MinutesPerDay is the number of minutes in a day, the upper bound of a TimeOfDay
+const MinutesPerDay = 24 * 60
+
+// ErrInvalidTimeOfDay is returned when a time of day falls outside of 00:00-23:59
+var ErrInvalidTimeOfDay = errors.New("time of day must be between 00:00 and 23:59")
+
+// NewTimeOfDay returns the TimeOfDay for the supplied hour (0-23) and minute (0-59)
+func NewTimeOfDay(hour, minute int) (TimeOfDay, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, ErrInvalidTimeOfDay
+	}
+	return TimeOfDay(hour*60 + minute), nil
+}
+
+// ParseTimeOfDay parses a 24 hour "HH:MM" string into a TimeOfDay
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, ErrInvalidTimeOfDay
+	}
+	return NewTimeOfDay(t.Hour(), t.Minute())
+}
+
+// TimeOfDayOf returns the TimeOfDay of the supplied time, in the time's location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay(t.Hour()*60 + t.Minute())
+}
+
+// Hour returns the hour of the day (0-23)
+func (t TimeOfDay) Hour() int {
+	return int(t) / 60
+}
+
+// Minute returns the minute within the hour (0-59)
+func (t TimeOfDay) Minute() int {
+	return int(t) % 60
+}
+
+// Valid indicates whether the TimeOfDay falls within a single day
+func (t TimeOfDay) Valid() bool {
+	return t >= 0 && t < MinutesPerDay
+}
+
+// On returns the time at which the TimeOfDay occurs on the same day as the supplied time
+func (t TimeOfDay) On(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, day.Location())
+}
+
+// Next returns the first occurrence of the TimeOfDay strictly after the supplied time
+func (t TimeOfDay) Next(after time.Time) time.Time {
+	next := t.On(after)
+	if !next.After(after) {
+		next = t.On(after.AddDate(0, 0, 1))
+	}
+	return next
+}
+
+// String returns the TimeOfDay in 24 hour "HH:MM" format
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour(), t.Minute())
+}
+
+// ScheduledDeparture holds the settings for a scheduled departure. Preconditioning
+// readies the cabin for the departure time, while off-peak charging delays charging
+// so that it completes by the end of the off-peak window
+type ScheduledDeparture struct {
+	Enable                      bool
+	DepartureTime               TimeOfDay
+	PreconditioningEnabled      bool
+	PreconditioningWeekdaysOnly bool
+	OffPeakChargingEnabled      bool
+	OffPeakChargingWeekdaysOnly bool
+	EndOffPeakTime              TimeOfDay
+}
+
+// SetScheduledCharging enables or disables scheduled charging, starting at the supplied time of day
+func (v Vehicle) SetScheduledCharging(enable bool, start TimeOfDay) error {
+	if !start.Valid() {
+		return ErrInvalidTimeOfDay
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_scheduled_charging"
+	body, _ := json.Marshal(scheduledChargingPayload{enable, start})
+	_, err := v.sendCommand(apiURL, body)
+	return err
+}
+
+// SetScheduledDeparture enables or disables scheduled departure with the supplied settings
+func (v Vehicle) SetScheduledDeparture(departure ScheduledDeparture) error {
+	if !departure.DepartureTime.Valid() || !departure.EndOffPeakTime.Valid() {
+		return ErrInvalidTimeOfDay
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_scheduled_departure"
+	departureRequest := scheduledDeparturePayload{
+		departure.Enable,
+		departure.DepartureTime,
+		departure.PreconditioningEnabled,
+		departure.PreconditioningWeekdaysOnly,
+		departure.OffPeakChargingEnabled,
+		departure.OffPeakChargingWeekdaysOnly,
+		departure.EndOffPeakTime,
+	}
+	body, _ := json.Marshal(departureRequest)
+	_, err := v.sendCommand(apiURL, body)
+	return err
+}
+
+// ScheduledChargingStart returns the time scheduled charging will start,
+// and false if no charging is scheduled
+func (s ChargeState) ScheduledChargingStart() (time.Time, bool) {
+	start := optionalEpochTime(s.ScheduledChargingStartTime)
+	return start, !start.IsZero()
+}
+
+// ScheduledDeparture returns the vehicle's scheduled departure settings as reported in the charge state
+func (s ChargeState) ScheduledDeparture() ScheduledDeparture {
+	return ScheduledDeparture{
+		Enable:                      s.ScheduledChargingMode == "DepartBy",
+		DepartureTime:               s.ScheduledDepartureTimeMinutes,
+		PreconditioningEnabled:      s.PreconditioningEnabled,
+		PreconditioningWeekdaysOnly: s.PreconditioningTimes == "weekdays",
+		OffPeakChargingEnabled:      s.OffPeakChargingEnabled,
+		OffPeakChargingWeekdaysOnly: s.OffPeakChargingTimes == "weekdays",
+		EndOffPeakTime:              s.OffPeakHoursEndTime,
+	}
+}
@@ -0,0 +1,98 @@
+package tesla
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ScheduledChargeStateJSON = `{"response":{"charging_state":"Stopped","scheduled_charging_mode":"DepartBy","scheduled_charging_pending":true,"scheduled_charging_start_time":1618729200,"scheduled_departure_time":1618743600,"scheduled_departure_time_minutes":480,"preconditioning_enabled":true,"preconditioning_times":"weekdays","off_peak_charging_enabled":true,"off_peak_charging_times":"all_week","off_peak_hours_end_time":360}}`
+)
+
+func TestTimeOfDaySpec(t *testing.T) {
+	Convey("Should build a time of day from hours and minutes", t, func() {
+		tod, err := NewTimeOfDay(7, 30)
+		So(err, ShouldBeNil)
+		So(int(tod), ShouldEqual, 450)
+		So(tod.Hour(), ShouldEqual, 7)
+		So(tod.Minute(), ShouldEqual, 30)
+		So(tod.String(), ShouldEqual, "07:30")
+	})
+
+	Convey("Should reject out of range times of day", t, func() {
+		_, err := NewTimeOfDay(24, 0)
+		So(err, ShouldEqual, ErrInvalidTimeOfDay)
+		_, err = NewTimeOfDay(12, 60)
+		So(err, ShouldEqual, ErrInvalidTimeOfDay)
+		So(TimeOfDay(MinutesPerDay).Valid(), ShouldBeFalse)
+		So(TimeOfDay(-1).Valid(), ShouldBeFalse)
+	})
+
+	Convey("Should parse a time of day", t, func() {
+		tod, err := ParseTimeOfDay("23:59")
+		So(err, ShouldBeNil)
+		So(int(tod), ShouldEqual, 1439)
+		_, err = ParseTimeOfDay("7h30")
+		So(err, ShouldEqual, ErrInvalidTimeOfDay)
+	})
+
+	Convey("Should find the next occurrence of a time of day", t, func() {
+		now := time.Date(2021, 4, 18, 8, 0, 0, 0, time.UTC)
+		So(TimeOfDay(450).Next(now), ShouldResemble, time.Date(2021, 4, 19, 7, 30, 0, 0, time.UTC))
+		So(TimeOfDay(540).Next(now), ShouldResemble, time.Date(2021, 4, 18, 9, 0, 0, 0, time.UTC))
+		So(TimeOfDayOf(now), ShouldEqual, TimeOfDay(480))
+	})
+}
+
+func TestScheduleSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should set scheduled charging", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.SetScheduledCharging(true, TimeOfDay(450))
+		So(err, ShouldBeNil)
+		err = vehicle.SetScheduledCharging(true, TimeOfDay(MinutesPerDay))
+		So(err, ShouldEqual, ErrInvalidTimeOfDay)
+	})
+
+	Convey("Should set scheduled departure", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.SetScheduledDeparture(ScheduledDeparture{
+			Enable:                      true,
+			DepartureTime:               TimeOfDay(480),
+			PreconditioningEnabled:      true,
+			PreconditioningWeekdaysOnly: true,
+			OffPeakChargingEnabled:      true,
+			EndOffPeakTime:              TimeOfDay(360),
+		})
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should parse the scheduled charge state", t, func() {
+		stateRequest := &StateRequest{}
+		err := json.Unmarshal([]byte(ScheduledChargeStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		chargeState := stateRequest.Response.ChargeState
+		start, ok := chargeState.ScheduledChargingStart()
+		So(ok, ShouldBeTrue)
+		So(start.Unix(), ShouldEqual, 1618729200)
+		departure := chargeState.ScheduledDeparture()
+		So(departure.Enable, ShouldBeTrue)
+		So(departure.DepartureTime.String(), ShouldEqual, "08:00")
+		So(departure.PreconditioningWeekdaysOnly, ShouldBeTrue)
+		So(departure.OffPeakChargingWeekdaysOnly, ShouldBeFalse)
+		So(departure.EndOffPeakTime.String(), ShouldEqual, "06:00")
+	})
+
+	Convey("Should report no scheduled charging start when unset", t, func() {
+		_, ok := ChargeState{}.ScheduledChargingStart()
+		So(ok, ShouldBeFalse)
+	})
+}
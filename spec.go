@@ -0,0 +1,8 @@
+package tesla
+
+import "github.com/rdbell/tesla/decoder"
+
+// Spec decodes the vehicle's VIN and option codes into a summary of its configuration
+func (v Vehicle) Spec() (*decoder.Spec, error) {
+	return decoder.Decode(v.Vin, v.OptionCodes)
+}
@@ -0,0 +1,28 @@
+package tesla
+
+import (
+	"testing"
+
+	"github.com/rdbell/tesla/decoder"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecSpec(t *testing.T) {
+	Convey("Should decode the vehicle's spec", t, func() {
+		vehicle := Vehicle{Vin: "5YJSA1E27HF000001", OptionCodes: "MS04,RENA,PBSB,DV4W,RFP2"}
+		spec, err := vehicle.Spec()
+		So(err, ShouldBeNil)
+		So(spec.Model, ShouldEqual, "Model S")
+		So(spec.ModelYear, ShouldEqual, 2017)
+		So(spec.VIN.Plant, ShouldEqual, "Fremont, California")
+		So(spec.Drive, ShouldEqual, "All-wheel drive")
+		So(spec.Paint, ShouldEqual, "Solid Black")
+		So(spec.Roof, ShouldEqual, "Sunroof")
+	})
+
+	Convey("Should return an error for an invalid VIN", t, func() {
+		spec, err := Vehicle{Vin: "abc123"}.Spec()
+		So(err, ShouldEqual, decoder.ErrInvalidVIN)
+		So(spec, ShouldBeNil)
+	})
+}
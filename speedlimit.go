@@ -0,0 +1,69 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrInvalidPIN is returned when a PIN is not exactly four digits
+	ErrInvalidPIN = errors.New("PIN must be four digits")
+	// ErrSpeedLimitOutOfRange is returned when a speed limit is outside of the vehicle's allowed range
+	ErrSpeedLimitOutOfRange = errors.New("speed limit out of range")
+)
+
+// ActivateSpeedLimit turns on speed limit mode, protected by the supplied four digit PIN
+func (v Vehicle) ActivateSpeedLimit(pin string) error {
+	return v.speedLimitPIN("speed_limit_activate", pin)
+}
+
+// DeactivateSpeedLimit turns off speed limit mode using the PIN it was activated with
+func (v Vehicle) DeactivateSpeedLimit(pin string) error {
+	return v.speedLimitPIN("speed_limit_deactivate", pin)
+}
+
+// ClearSpeedLimitPIN clears the speed limit mode PIN
+func (v Vehicle) ClearSpeedLimitPIN(pin string) error {
+	return v.speedLimitPIN("speed_limit_clear_pin", pin)
+}
+
+// SetSpeedLimit sets the maximum speed in mph, validated against the vehicle's allowed range
+func (v Vehicle) SetSpeedLimit(limitMph int) error {
+	vehicleState, err := v.VehicleState()
+	if err != nil {
+		return err
+	}
+	speedLimitMode := vehicleState.SpeedLimitMode
+	if speedLimitMode.MaxLimitMph > 0 && (limitMph < speedLimitMode.MinLimitMph || limitMph > speedLimitMode.MaxLimitMph) {
+		return ErrSpeedLimitOutOfRange
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/speed_limit_set_limit"
+	body, _ := json.Marshal(speedLimitPayload{limitMph})
+	_, err = v.sendCommand(apiURL, body)
+	return err
+}
+
+// speedLimitPIN sends a speed limit mode command that requires the PIN
+func (v Vehicle) speedLimitPIN(command, pin string) error {
+	if !validPIN(pin) {
+		return ErrInvalidPIN
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/" + command
+	body, _ := json.Marshal(pinPayload{pin})
+	_, err := v.sendCommand(apiURL, body)
+	return err
+}
+
+// validPIN checks that a PIN is four digits
+func validPIN(pin string) bool {
+	if len(pin) != 4 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
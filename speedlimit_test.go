@@ -0,0 +1,38 @@
+package tesla
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpeedLimitSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should activate and deactivate speed limit mode", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.ActivateSpeedLimit("1234"), ShouldBeNil)
+		So(vehicle.DeactivateSpeedLimit("1234"), ShouldBeNil)
+		So(vehicle.ClearSpeedLimitPIN("1234"), ShouldBeNil)
+	})
+
+	Convey("Should reject invalid PINs", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.ActivateSpeedLimit("123"), ShouldEqual, ErrInvalidPIN)
+		So(vehicle.DeactivateSpeedLimit("12a4"), ShouldEqual, ErrInvalidPIN)
+		So(vehicle.ClearSpeedLimitPIN(""), ShouldEqual, ErrInvalidPIN)
+	})
+
+	Convey("Should set the speed limit within range", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.SetSpeedLimit(65), ShouldBeNil)
+		So(vehicle.SetSpeedLimit(45), ShouldEqual, ErrSpeedLimitOutOfRange)
+		So(vehicle.SetSpeedLimit(95), ShouldEqual, ErrSpeedLimitOutOfRange)
+	})
+}
@@ -1,66 +1,161 @@
 package tesla
 
 import (
+	"context"
 	"encoding/json"
+	"net/url"
 	"strconv"
 )
 
-// VehicleData represents the full set of vehicle data
+// VehicleData represents the full set of vehicle data. States that weren't requested, or that
+// the vehicle didn't report, are nil
 type VehicleData struct {
 	Vehicle
-	ChargeState   ChargeState   `json:"charge_state"`
-	ClimateState  ClimateState  `json:"climate_state"`
-	DriveState    DriveState    `json:"drive_state"`
-	GuiSettings   GuiSettings   `json:"gui_settings"`
-	VehicleConfig VehicleConfig `json:"vehicle_config"`
-	VehicleState  VehicleState  `json:"vehicle_state"`
+	ChargeState   *ChargeState   `json:"charge_state"`
+	ClimateState  *ClimateState  `json:"climate_state"`
+	DriveState    *DriveState    `json:"drive_state"`
+	GuiSettings   *GuiSettings   `json:"gui_settings"`
+	VehicleConfig *VehicleConfig `json:"vehicle_config"`
+	VehicleState  *VehicleState  `json:"vehicle_state"`
+
+	// present records the JSON paths the data was decoded with, and whether each was null, so
+	// Diff can tell fields the API left out from zero values
+	present map[string]bool
+}
+
+// UnmarshalJSON decodes the vehicle data, recording which fields the API sent
+func (d *VehicleData) UnmarshalJSON(data []byte) error {
+	type vehicleData VehicleData
+	err := json.Unmarshal(data, (*vehicleData)(d))
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	d.present = map[string]bool{}
+	recordPaths("", fields, d.present)
+	return nil
 }
 
+// VehicleDataOption selects what VehicleDataWith requests
+type VehicleDataOption interface {
+	apply(query url.Values)
+}
+
+// VehicleDataEndpoint is a section of the vehicle data that can be requested on its own
+type VehicleDataEndpoint string
+
+// Vehicle data endpoints. LocationDataEndpoint adds the vehicle's location to the drive state,
+// which is otherwise omitted
+const (
+	ChargeStateEndpoint   VehicleDataEndpoint = "charge_state"
+	ClimateStateEndpoint  VehicleDataEndpoint = "climate_state"
+	DriveStateEndpoint    VehicleDataEndpoint = "drive_state"
+	GuiSettingsEndpoint   VehicleDataEndpoint = "gui_settings"
+	LocationDataEndpoint  VehicleDataEndpoint = "location_data"
+	VehicleConfigEndpoint VehicleDataEndpoint = "vehicle_config"
+	VehicleStateEndpoint  VehicleDataEndpoint = "vehicle_state"
+)
+
+// apply adds the endpoint to the semicolon separated endpoints query
+func (e VehicleDataEndpoint) apply(query url.Values) {
+	endpoints := query.Get("endpoints")
+	if endpoints != "" {
+		endpoints += ";"
+	}
+	query.Set("endpoints", endpoints+string(e))
+}
+
+type letSleep struct{}
+
+// apply asks the vehicle not to stay awake for the request
+func (letSleep) apply(query url.Values) {
+	query.Set("let_sleep", "true")
+}
+
+// LetSleep requests the vehicle data without keeping the vehicle awake
+var LetSleep VehicleDataOption = letSleep{}
+
+// ChargingState is the state of the vehicle's charging session
+type ChargingState string
+
+// Charging states reported in the charge state
+const (
+	ChargingDisconnected ChargingState = "Disconnected"
+	ChargingNoPower      ChargingState = "NoPower"
+	ChargingStarting     ChargingState = "Starting"
+	ChargingCharging     ChargingState = "Charging"
+	ChargingComplete     ChargingState = "Complete"
+	ChargingStopped      ChargingState = "Stopped"
+)
+
+// ShiftState is the gear the vehicle is in. It is empty while the vehicle is switched off
+type ShiftState string
+
+// Shift states reported in the drive state
+const (
+	ShiftPark    ShiftState = "P"
+	ShiftReverse ShiftState = "R"
+	ShiftNeutral ShiftState = "N"
+	ShiftDrive   ShiftState = "D"
+)
+
 // ChargeState contains the current charge states that exist within the vehicle
 type ChargeState struct {
-	BatteryHeaterOn             bool        `json:"battery_heater_on"`
-	BatteryLevel                int         `json:"battery_level"`
-	BatteryRange                float64     `json:"battery_range"`
-	ChargeCurrentRequest        int         `json:"charge_current_request"`
-	ChargeCurrentRequestMax     int         `json:"charge_current_request_max"`
-	ChargeEnableRequest         bool        `json:"charge_enable_request"`
-	ChargeEnergyAdded           float64     `json:"charge_energy_added"`
-	ChargeLimitSoc              int         `json:"charge_limit_soc"`
-	ChargeLimitSocMax           int         `json:"charge_limit_soc_max"`
-	ChargeLimitSocMin           int         `json:"charge_limit_soc_min"`
-	ChargeLimitSocStd           int         `json:"charge_limit_soc_std"`
-	ChargeMilesAddedIdeal       float64     `json:"charge_miles_added_ideal"`
-	ChargeMilesAddedRated       float64     `json:"charge_miles_added_rated"`
-	ChargePortColdWeatherMode   interface{} `json:"charge_port_cold_weather_mode"`
-	ChargePortDoorOpen          bool        `json:"charge_port_door_open"`
-	ChargePortLatch             string      `json:"charge_port_latch"`
-	ChargeRate                  float64     `json:"charge_rate"`
-	ChargeToMaxRange            bool        `json:"charge_to_max_range"`
-	ChargerActualCurrent        int         `json:"charger_actual_current"`
-	ChargerPhases               interface{} `json:"charger_phases"`
-	ChargerPilotCurrent         int         `json:"charger_pilot_current"`
-	ChargerPower                int         `json:"charger_power"`
-	ChargerVoltage              int         `json:"charger_voltage"`
-	ChargingState               string      `json:"charging_state"`
-	ConnChargeCable             string      `json:"conn_charge_cable"`
-	EstBatteryRange             float64     `json:"est_battery_range"`
-	FastChargerBrand            string      `json:"fast_charger_brand"`
-	FastChargerPresent          bool        `json:"fast_charger_present"`
-	FastChargerType             string      `json:"fast_charger_type"`
-	IdealBatteryRange           float64     `json:"ideal_battery_range"`
-	ManagedChargingActive       bool        `json:"managed_charging_active"`
-	ManagedChargingStartTime    interface{} `json:"managed_charging_start_time"`
-	ManagedChargingUserCanceled bool        `json:"managed_charging_user_canceled"`
-	MaxRangeChargeCounter       int         `json:"max_range_charge_counter"`
-	MinutesToFullCharge         int         `json:"minutes_to_full_charge"`
-	NotEnoughPowerToHeat        bool        `json:"not_enough_power_to_heat"`
-	ScheduledChargingPending    bool        `json:"scheduled_charging_pending"`
-	ScheduledChargingStartTime  interface{} `json:"scheduled_charging_start_time"`
-	TimeToFullCharge            float64     `json:"time_to_full_charge"`
-	Timestamp                   int64       `json:"timestamp"`
-	TripCharging                bool        `json:"trip_charging"`
-	UsableBatteryLevel          int         `json:"usable_battery_level"`
-	UserChargeEnableRequest     bool        `json:"user_charge_enable_request"`
+	BatteryHeaterOn               bool          `json:"battery_heater_on"`
+	BatteryLevel                  int           `json:"battery_level"`
+	BatteryRange                  float64       `json:"battery_range"`
+	ChargeCurrentRequest          int           `json:"charge_current_request"`
+	ChargeCurrentRequestMax       int           `json:"charge_current_request_max"`
+	ChargeEnableRequest           bool          `json:"charge_enable_request"`
+	ChargeEnergyAdded             float64       `json:"charge_energy_added"`
+	ChargeLimitSoc                int           `json:"charge_limit_soc"`
+	ChargeLimitSocMax             int           `json:"charge_limit_soc_max"`
+	ChargeLimitSocMin             int           `json:"charge_limit_soc_min"`
+	ChargeLimitSocStd             int           `json:"charge_limit_soc_std"`
+	ChargeMilesAddedIdeal         float64       `json:"charge_miles_added_ideal"`
+	ChargeMilesAddedRated         float64       `json:"charge_miles_added_rated"`
+	ChargePortColdWeatherMode     *bool         `json:"charge_port_cold_weather_mode"`
+	ChargePortDoorOpen            bool          `json:"charge_port_door_open"`
+	ChargePortLatch               string        `json:"charge_port_latch"`
+	ChargeRate                    float64       `json:"charge_rate"`
+	ChargeToMaxRange              bool          `json:"charge_to_max_range"`
+	ChargerActualCurrent          int           `json:"charger_actual_current"`
+	ChargerPhases                 *int64        `json:"charger_phases"`
+	ChargerPilotCurrent           int           `json:"charger_pilot_current"`
+	ChargerPower                  int           `json:"charger_power"`
+	ChargerVoltage                int           `json:"charger_voltage"`
+	ChargingState                 ChargingState `json:"charging_state"`
+	ConnChargeCable               string        `json:"conn_charge_cable"`
+	EstBatteryRange               float64       `json:"est_battery_range"`
+	FastChargerBrand              string        `json:"fast_charger_brand"`
+	FastChargerPresent            bool          `json:"fast_charger_present"`
+	FastChargerType               string        `json:"fast_charger_type"`
+	IdealBatteryRange             float64       `json:"ideal_battery_range"`
+	ManagedChargingActive         bool          `json:"managed_charging_active"`
+	ManagedChargingStartTime      *int64        `json:"managed_charging_start_time"`
+	ManagedChargingUserCanceled   bool          `json:"managed_charging_user_canceled"`
+	MaxRangeChargeCounter         int           `json:"max_range_charge_counter"`
+	MinutesToFullCharge           int           `json:"minutes_to_full_charge"`
+	NotEnoughPowerToHeat          bool          `json:"not_enough_power_to_heat"`
+	OffPeakChargingEnabled        bool          `json:"off_peak_charging_enabled"`
+	OffPeakChargingTimes          string        `json:"off_peak_charging_times"`
+	OffPeakHoursEndTime           TimeOfDay     `json:"off_peak_hours_end_time"`
+	PreconditioningEnabled        bool          `json:"preconditioning_enabled"`
+	PreconditioningTimes          string        `json:"preconditioning_times"`
+	ScheduledChargingMode         string        `json:"scheduled_charging_mode"`
+	ScheduledChargingPending      bool          `json:"scheduled_charging_pending"`
+	ScheduledChargingStartTime    *int64        `json:"scheduled_charging_start_time"`
+	ScheduledDepartureTime        int64         `json:"scheduled_departure_time"`
+	ScheduledDepartureTimeMinutes TimeOfDay     `json:"scheduled_departure_time_minutes"`
+	TimeToFullCharge              float64       `json:"time_to_full_charge"`
+	Timestamp                     int64         `json:"timestamp"`
+	TripCharging                  bool          `json:"trip_charging"`
+	UsableBatteryLevel            int           `json:"usable_battery_level"`
+	UserChargeEnableRequest       bool          `json:"user_charge_enable_request"`
 }
 
 // ClimateState contains the current climate states availale from the vehicle
@@ -99,18 +194,18 @@ type ClimateState struct {
 
 // DriveState contains the current drive state of the vehicle
 type DriveState struct {
-	GpsAsOf                 int         `json:"gps_as_of"`
-	Heading                 int         `json:"heading"`
-	Latitude                float64     `json:"latitude"`
-	Longitude               float64     `json:"longitude"`
-	NativeLatitude          float64     `json:"native_latitude"`
-	NativeLocationSupported int         `json:"native_location_supported"`
-	NativeLongitude         float64     `json:"native_longitude"`
-	NativeType              string      `json:"native_type"`
-	Power                   int         `json:"power"`
-	ShiftState              string      `json:"shift_state"`
-	Speed                   interface{} `json:"speed"`
-	Timestamp               int64       `json:"timestamp"`
+	GpsAsOf                 int        `json:"gps_as_of"`
+	Heading                 int        `json:"heading"`
+	Latitude                float64    `json:"latitude"`
+	Longitude               float64    `json:"longitude"`
+	NativeLatitude          float64    `json:"native_latitude"`
+	NativeLocationSupported int        `json:"native_location_supported"`
+	NativeLongitude         float64    `json:"native_longitude"`
+	NativeType              string     `json:"native_type"`
+	Power                   int        `json:"power"`
+	ShiftState              ShiftState `json:"shift_state"`
+	Speed                   *float64   `json:"speed"`
+	Timestamp               int64      `json:"timestamp"`
 }
 
 // GuiSettings contains the current GUI settings of the vehicle
@@ -152,25 +247,43 @@ type VehicleConfig struct {
 	WheelType                   string `json:"wheel_type"`
 }
 
-// VehicleState contains the current state of the vehicle
+// VehicleState contains the current state of the vehicle. The door and trunk fields (Df, Dr,
+// Pf, Pr, Ft and Rt) are raw values; use Doors for a typed view of them, and Tires for the TPMS fields
 type VehicleState struct {
-	APIVersion          int    `json:"api_version"`
-	AutoparkStateV2     string `json:"autopark_state_v2"`
-	AutoparkStyle       string `json:"autopark_style"`
-	CalendarSupported   bool   `json:"calendar_supported"`
-	CarVersion          string `json:"car_version"`
-	CenterDisplayState  int    `json:"center_display_state"`
-	Df                  int    `json:"df"`
-	Dr                  int    `json:"dr"`
-	FdWindow            int    `json:"fd_window"`
-	FpWindow            int    `json:"fp_window"`
-	Ft                  int    `json:"ft"`
-	HomelinkDeviceCount int    `json:"homelink_device_count"`
-	HomelinkNearby      bool   `json:"homelink_nearby"`
-	IsUserPresent       bool   `json:"is_user_present"`
-	LastAutoparkError   string `json:"last_autopark_error"`
-	Locked              bool   `json:"locked"`
-	MediaState          struct {
+	APIVersion               int    `json:"api_version"`
+	AutoparkStateV2          string `json:"autopark_state_v2"`
+	AutoparkStateV3          string `json:"autopark_state_v3"`
+	AutoparkStyle            string `json:"autopark_style"`
+	CalendarSupported        bool   `json:"calendar_supported"`
+	CarVersion               string `json:"car_version"`
+	CenterDisplayState       int    `json:"center_display_state"`
+	DashcamClipSaveAvailable bool   `json:"dashcam_clip_save_available"`
+	DashcamState             string `json:"dashcam_state"`
+	Df                       int    `json:"df"`
+	Dr                       int    `json:"dr"`
+	FdWindow                 int    `json:"fd_window"`
+	FeatureBitmask           string `json:"feature_bitmask"`
+	FpWindow                 int    `json:"fp_window"`
+	Ft                       int    `json:"ft"`
+	HomelinkDeviceCount      int    `json:"homelink_device_count"`
+	HomelinkNearby           bool   `json:"homelink_nearby"`
+	IsUserPresent            bool   `json:"is_user_present"`
+	LastAutoparkError        string `json:"last_autopark_error"`
+	Locked                   bool   `json:"locked"`
+	MediaInfo                struct {
+		AudioVolume          float64 `json:"audio_volume"`
+		AudioVolumeIncrement float64 `json:"audio_volume_increment"`
+		AudioVolumeMax       float64 `json:"audio_volume_max"`
+		MediaPlaybackStatus  string  `json:"media_playback_status"`
+		NowPlayingAlbum      string  `json:"now_playing_album"`
+		NowPlayingArtist     string  `json:"now_playing_artist"`
+		NowPlayingDuration   int     `json:"now_playing_duration"`
+		NowPlayingElapsed    int     `json:"now_playing_elapsed"`
+		NowPlayingSource     string  `json:"now_playing_source"`
+		NowPlayingStation    string  `json:"now_playing_station"`
+		NowPlayingTitle      string  `json:"now_playing_title"`
+	} `json:"media_info"`
+	MediaState struct {
 		RemoteControlEnabled bool `json:"remote_control_enabled"`
 	} `json:"media_state"`
 	NotificationsSupported  bool    `json:"notifications_supported"`
@@ -184,15 +297,20 @@ type VehicleState struct {
 	RemoteStartSupported    bool    `json:"remote_start_supported"`
 	RpWindow                int     `json:"rp_window"`
 	Rt                      int     `json:"rt"`
+	SantaMode               int     `json:"santa_mode"`
 	SentryMode              bool    `json:"sentry_mode"`
 	SentryModeAvailable     bool    `json:"sentry_mode_available"`
+	ServiceMode             bool    `json:"service_mode"`
+	ServiceModePlus         bool    `json:"service_mode_plus"`
 	SmartSummonAvailable    bool    `json:"smart_summon_available"`
 	SoftwareUpdate          struct {
-		DownloadPerc        int    `json:"download_perc"`
-		ExpectedDurationSec int    `json:"expected_duration_sec"`
-		InstallPerc         int    `json:"install_perc"`
-		Status              string `json:"status"`
-		Version             string `json:"version"`
+		DownloadPerc           int    `json:"download_perc"`
+		ExpectedDurationSec    int    `json:"expected_duration_sec"`
+		InstallPerc            int    `json:"install_perc"`
+		ScheduledTimeMs        int64  `json:"scheduled_time_ms"`
+		Status                 string `json:"status"`
+		Version                string `json:"version"`
+		WarningTimeRemainingMs int64  `json:"warning_time_remaining_ms"`
 	} `json:"software_update"`
 	SpeedLimitMode struct {
 		Active          bool    `json:"active"`
@@ -201,11 +319,34 @@ type VehicleState struct {
 		MinLimitMph     int     `json:"min_limit_mph"`
 		PinCodeSet      bool    `json:"pin_code_set"`
 	} `json:"speed_limit_mode"`
-	SummonStandbyModeEnabled bool   `json:"summon_standby_mode_enabled"`
-	Timestamp                int64  `json:"timestamp"`
-	ValetMode                bool   `json:"valet_mode"`
-	ValetPinNeeded           bool   `json:"valet_pin_needed"`
-	VehicleName              string `json:"vehicle_name"`
+	SummonStandbyModeEnabled   bool     `json:"summon_standby_mode_enabled"`
+	SunRoofPercentOpen         *int64   `json:"sun_roof_percent_open"`
+	SunRoofState               string   `json:"sun_roof_state"`
+	Timestamp                  int64    `json:"timestamp"`
+	TpmsHardWarningFl          bool     `json:"tpms_hard_warning_fl"`
+	TpmsHardWarningFr          bool     `json:"tpms_hard_warning_fr"`
+	TpmsHardWarningRl          bool     `json:"tpms_hard_warning_rl"`
+	TpmsHardWarningRr          bool     `json:"tpms_hard_warning_rr"`
+	TpmsLastSeenPressureTimeFl *int64   `json:"tpms_last_seen_pressure_time_fl"`
+	TpmsLastSeenPressureTimeFr *int64   `json:"tpms_last_seen_pressure_time_fr"`
+	TpmsLastSeenPressureTimeRl *int64   `json:"tpms_last_seen_pressure_time_rl"`
+	TpmsLastSeenPressureTimeRr *int64   `json:"tpms_last_seen_pressure_time_rr"`
+	TpmsPressureFl             *float64 `json:"tpms_pressure_fl"`
+	TpmsPressureFr             *float64 `json:"tpms_pressure_fr"`
+	TpmsPressureRl             *float64 `json:"tpms_pressure_rl"`
+	TpmsPressureRr             *float64 `json:"tpms_pressure_rr"`
+	TpmsRcpFrontValue          *float64 `json:"tpms_rcp_front_value"`
+	TpmsRcpRearValue           *float64 `json:"tpms_rcp_rear_value"`
+	TpmsSoftWarningFl          bool     `json:"tpms_soft_warning_fl"`
+	TpmsSoftWarningFr          bool     `json:"tpms_soft_warning_fr"`
+	TpmsSoftWarningRl          bool     `json:"tpms_soft_warning_rl"`
+	TpmsSoftWarningRr          bool     `json:"tpms_soft_warning_rr"`
+	ValetMode                  bool     `json:"valet_mode"`
+	ValetPinNeeded             bool     `json:"valet_pin_needed"`
+	VehicleName                string   `json:"vehicle_name"`
+	VehicleSelfTestProgress    int      `json:"vehicle_self_test_progress"`
+	VehicleSelfTestRequested   bool     `json:"vehicle_self_test_requested"`
+	WebcamAvailable            bool     `json:"webcam_available"`
 }
 
 // StateRequest represents the request to get the states of the vehicle
@@ -227,7 +368,7 @@ type Response struct {
 
 // MobileEnabled returns a flag indicating whether the vehicle is mobile enabled for Tesla API control
 func (v *Vehicle) MobileEnabled() (bool, error) {
-	body, err := ActiveClient.get(BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/mobile_enabled")
+	body, err := v.apiClient().get(BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/mobile_enabled")
 	if err != nil {
 		return false, err
 	}
@@ -241,64 +382,89 @@ func (v *Vehicle) MobileEnabled() (bool, error) {
 
 // ChargeState returns the charge state of the vehicle
 func (v *Vehicle) ChargeState() (*ChargeState, error) {
-	stateRequest, err := fetchState("/charge_state", v.ID)
+	chargeState := &ChargeState{}
+	err := v.fetchState("/charge_state", chargeState)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.ChargeState, nil
+	return chargeState, nil
 }
 
 // ClimateState returns the climate state of the vehicle
 func (v Vehicle) ClimateState() (*ClimateState, error) {
-	stateRequest, err := fetchState("/climate_state", v.ID)
+	climateState := &ClimateState{}
+	err := v.fetchState("/climate_state", climateState)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.ClimateState, nil
+	return climateState, nil
 }
 
 // DriveState returns the drive state of the vehicle
 func (v Vehicle) DriveState() (*DriveState, error) {
-	stateRequest, err := fetchState("/drive_state", v.ID)
+	driveState := &DriveState{}
+	err := v.fetchState("/drive_state", driveState)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.DriveState, nil
+	return driveState, nil
 }
 
 // GuiSettings returns the GUI settings of the vehicle
 func (v Vehicle) GuiSettings() (*GuiSettings, error) {
-	stateRequest, err := fetchState("/gui_settings", v.ID)
+	guiSettings := &GuiSettings{}
+	err := v.fetchState("/gui_settings", guiSettings)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.GuiSettings, nil
+	return guiSettings, nil
 }
 
 // VehicleConfig retrieves the vehicle's configured features
 func (v Vehicle) VehicleConfig() (*VehicleConfig, error) {
-	stateRequest, err := fetchState("/vehicle_config", v.ID)
+	vehicleConfig := &VehicleConfig{}
+	err := v.fetchState("/vehicle_config", vehicleConfig)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.VehicleConfig, nil
+	return vehicleConfig, nil
 }
 
 // VehicleState returns the vehicle state
 func (v Vehicle) VehicleState() (*VehicleState, error) {
-	stateRequest, err := fetchState("/vehicle_state", v.ID)
+	vehicleState := &VehicleState{}
+	err := v.fetchState("/vehicle_state", vehicleState)
 	if err != nil {
 		return nil, err
 	}
-	return stateRequest.Response.VehicleState, nil
+	return vehicleState, nil
 }
 
-// VehicleData retrieves the full set of vehicle data.
+// VehicleData retrieves the full set of vehicle data, except for the vehicle's location.
 func (v Vehicle) VehicleData() (*VehicleData, error) {
+	return v.VehicleDataWith()
+}
+
+// VehicleDataWith retrieves the vehicle data from the supplied endpoints, or all of them except
+// the location if none are supplied. Pass LetSleep to avoid keeping the vehicle awake
+func (v Vehicle) VehicleDataWith(options ...VehicleDataOption) (*VehicleData, error) {
+	return v.vehicleDataWith(context.Background(), options...)
+}
+
+// vehicleDataWith retrieves the vehicle data, as part of the supplied context
+func (v Vehicle) vehicleDataWith(ctx context.Context, options ...VehicleDataOption) (*VehicleData, error) {
 	resp := &struct {
 		VehicleData VehicleData `json:"response"`
 	}{}
-	body, err := ActiveClient.get(BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/vehicle_data")
+	query := url.Values{}
+	for _, option := range options {
+		option.apply(query)
+	}
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/vehicle_data"
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+	body, err := v.apiClient().getContext(ctx, apiURL)
 	if err != nil {
 		return nil, err
 	}
@@ -309,16 +475,21 @@ func (v Vehicle) VehicleData() (*VehicleData, error) {
 	return &resp.VehicleData, nil
 }
 
-// fetchState fetches the a given state of the vehicle
-func fetchState(resource string, id int64) (*StateRequest, error) {
-	stateRequest := &StateRequest{}
-	body, err := ActiveClient.get(BaseURL + "/vehicles/" + strconv.FormatInt(id, 10) + "/data_request" + resource)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(body, stateRequest)
+// fetchState fetches the a given state of the vehicle into the supplied state. Decoding into the
+// state itself, rather than a StateRequest, populates the fields that every state shares, such as
+// the timestamp
+func (v Vehicle) fetchState(resource string, state interface{}) error {
+	return v.fetchStateContext(context.Background(), resource, state)
+}
+
+// fetchStateContext fetches a given state of the vehicle as part of the supplied context
+func (v Vehicle) fetchStateContext(ctx context.Context, resource string, state interface{}) error {
+	stateResponse := &struct {
+		Response interface{} `json:"response"`
+	}{state}
+	body, err := v.apiClient().getContext(ctx, BaseURL+"/vehicles/"+strconv.FormatInt(v.ID, 10)+"/data_request"+resource)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return stateRequest, nil
+	return json.Unmarshal(body, stateResponse)
 }
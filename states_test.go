@@ -7,12 +7,15 @@ import (
 )
 
 var (
-	TrueJSON         = `{"response":true}`
-	ChargeStateJSON  = `{"response":{"charging_state":"Complete","charge_limit_soc":90,"charge_limit_soc_std":90,"charge_limit_soc_min":50,"charge_limit_soc_max":100,"charge_to_max_range":false,"battery_heater_on":null,"not_enough_power_to_heat":null,"max_range_charge_counter":0,"fast_charger_present":null,"fast_charger_type":"\u003Cinvalid\u003E","battery_range":235.92,"est_battery_range":200.46,"ideal_battery_range":304.73,"battery_level":90,"usable_battery_level":90,"battery_current":null,"charge_energy_added":19.94,"charge_miles_added_rated":64.5,"charge_miles_added_ideal":83.0,"charger_voltage":null,"charger_pilot_current":null,"charger_actual_current":null,"charger_power":null,"time_to_full_charge":0.0,"trip_charging":null,"charge_rate":0.0,"charge_port_door_open":null,"motorized_charge_port":true,"scheduled_charging_start_time":null,"scheduled_charging_pending":false,"user_charge_enable_request":null,"charge_enable_request":true,"eu_vehicle":false,"charger_phases":null,"charge_port_latch":"\u003Cinvalid\u003E","charge_current_request":40,"charge_current_request_max":40,"managed_charging_active":false,"managed_charging_user_canceled":false,"managed_charging_start_time":null}}`
-	ClimateStateJSON = `{"response":{"inside_temp":null,"outside_temp":null,"driver_temp_setting":22.0,"passenger_temp_setting":22.0,"left_temp_direction":17,"right_temp_direction":17,"is_auto_conditioning_on":null,"is_front_defroster_on":null,"is_rear_defroster_on":false,"fan_status":null,"is_climate_on":false,"min_avail_temp":15,"max_avail_temp":28,"seat_heater_left":0,"seat_heater_right":0,"seat_heater_rear_left":0,"seat_heater_rear_right":0,"seat_heater_rear_center":0,"seat_heater_rear_right_back":0,"seat_heater_rear_left_back":0,"smart_preconditioning":false}}`
-	DriveStateJSON   = `{"response":{"shift_state":null,"speed":null,"latitude":35.1,"longitude":20.2,"heading":57,"gps_as_of":1452491619}}`
-	GuiSettingsJSON  = `{"response":{"gui_distance_units":"mi/hr","gui_temperature_units":"F","gui_charge_rate_units":"mi/hr","gui_24_hour_time":true,"gui_range_display":"Rated"}}`
-	VehicleStateJSON = `{"response":{"api_version":3,"calendar_supported":true,"car_type":"s","car_version":"2.9.12","center_display_state":0,"dark_rims":false,"df":0,"dr":0,"exterior_color":"Black","ft":0,"has_spoiler":true,"locked":true,"notifications_supported":true,"odometer":3738.84633,"parsed_calendar_supported":true,"perf_config":"P2","pf":0,"pr":0,"rear_seat_heaters":1,"remote_start":false,"remote_start_supported":true,"rhd":false,"roof_color":"None","rt":0,"seat_type":1,"sun_roof_installed":2,"sun_roof_percent_open":0,"sun_roof_state":"unknown","third_row_seats":"None","valet_mode":false,"vehicle_name":"Macak","wheel_type":"Super21Gray"}}`
+	TrueJSON          = `{"response":true}`
+	ChargeStateJSON   = `{"response":{"charging_state":"Complete","charge_limit_soc":90,"charge_limit_soc_std":90,"charge_limit_soc_min":50,"charge_limit_soc_max":100,"charge_to_max_range":false,"battery_heater_on":null,"not_enough_power_to_heat":null,"max_range_charge_counter":0,"fast_charger_present":null,"fast_charger_type":"\u003Cinvalid\u003E","battery_range":235.92,"est_battery_range":200.46,"ideal_battery_range":304.73,"battery_level":90,"usable_battery_level":90,"battery_current":null,"charge_energy_added":19.94,"charge_miles_added_rated":64.5,"charge_miles_added_ideal":83.0,"charger_voltage":null,"charger_pilot_current":null,"charger_actual_current":null,"charger_power":null,"time_to_full_charge":0.0,"trip_charging":null,"charge_rate":0.0,"charge_port_door_open":null,"motorized_charge_port":true,"scheduled_charging_start_time":null,"scheduled_charging_pending":false,"user_charge_enable_request":null,"charge_enable_request":true,"eu_vehicle":false,"charger_phases":null,"charge_port_latch":"\u003Cinvalid\u003E","charge_current_request":40,"charge_current_request_max":40,"managed_charging_active":false,"managed_charging_user_canceled":false,"managed_charging_start_time":null}}`
+	ClimateStateJSON  = `{"response":{"inside_temp":null,"outside_temp":null,"driver_temp_setting":22.0,"passenger_temp_setting":22.0,"left_temp_direction":17,"right_temp_direction":17,"is_auto_conditioning_on":null,"is_front_defroster_on":null,"is_rear_defroster_on":false,"fan_status":null,"is_climate_on":false,"min_avail_temp":15,"max_avail_temp":28,"seat_heater_left":0,"seat_heater_right":0,"seat_heater_rear_left":0,"seat_heater_rear_right":0,"seat_heater_rear_center":0,"seat_heater_rear_right_back":0,"seat_heater_rear_left_back":0,"smart_preconditioning":false}}`
+	DriveStateJSON    = `{"response":{"shift_state":null,"speed":null,"latitude":35.1,"longitude":20.2,"heading":57,"gps_as_of":1452491619}}`
+	GuiSettingsJSON   = `{"response":{"gui_distance_units":"mi/hr","gui_temperature_units":"F","gui_charge_rate_units":"mi/hr","gui_24_hour_time":true,"gui_range_display":"Rated"}}`
+	VehicleConfigJSON = `{"response":{"can_accept_navigation_requests":true,"can_actuate_trunks":true,"car_special_type":"base","car_type":"models2","charge_port_type":"US","eu_vehicle":false,"exterior_color":"Black","has_air_suspension":true,"has_ludicrous_mode":false,"motorized_charge_port":true,"perf_config":"P2","plg":true,"rear_seat_heaters":1,"rear_seat_type":0,"rhd":false,"roof_color":"None","seat_type":1,"spoiler_type":"None","sun_roof_installed":2,"third_row_seats":"None","timestamp":1543186971731,"trim_badging":"p90d","wheel_type":"Super21Gray"}}`
+	VehicleStateJSON  = `{"response":{"api_version":3,"calendar_supported":true,"car_type":"s","car_version":"2.9.12","center_display_state":0,"dark_rims":false,"df":0,"dr":0,"exterior_color":"Black","ft":0,"has_spoiler":true,"locked":true,"media_state":{"remote_control_enabled":true},"notifications_supported":true,"odometer":3738.84633,"parsed_calendar_supported":true,"perf_config":"P2","pf":0,"pr":0,"rear_seat_heaters":1,"remote_start":false,"remote_start_supported":true,"rhd":false,"roof_color":"None","rt":0,"seat_type":1,"sun_roof_installed":2,"sun_roof_percent_open":0,"speed_limit_mode":{"active":false,"current_limit_mph":85.0,"max_limit_mph":90,"min_limit_mph":50,"pin_code_set":false},"sun_roof_state":"unknown","third_row_seats":"None","valet_mode":false,"vehicle_name":"Macak","wheel_type":"Super21Gray"}}`
+	VehicleDataJSON   = `{"response":{"id":1234,"vin":"abc123","state":"online","charge_state":{"battery_level":80,"battery_range":235.92,"timestamp":1543186971731},"climate_state":{"inside_temp":21.5,"timestamp":1543186971731},"drive_state":{"shift_state":null,"speed":null,"heading":57,"timestamp":1543186971731},"gui_settings":{"gui_distance_units":"mi/hr"},"vehicle_config":{"car_type":"models2"},"vehicle_state":{"locked":true,"odometer":3738.84633}}}`
+	LocationDataJSON  = `{"response":{"id":1234,"vin":"abc123","state":"online","charge_state":{"battery_level":80,"timestamp":1543186971731},"drive_state":{"latitude":35.1,"longitude":20.2,"gps_as_of":1452491619,"timestamp":1543186971731}}}`
 )
 
 func TestStatesSpec(t *testing.T) {
@@ -47,7 +50,7 @@ func TestStatesSpec(t *testing.T) {
 		So(err, ShouldBeNil)
 		So(status.BatteryLevel, ShouldEqual, 90)
 		So(status.ChargeRate, ShouldEqual, 0)
-		So(status.ChargingState, ShouldEqual, "Complete")
+		So(status.ChargingState, ShouldEqual, ChargingComplete)
 	})
 
 	Convey("Should get climate state", t, func() {
@@ -78,6 +81,15 @@ func TestStatesSpec(t *testing.T) {
 		So(status.GuiTemperatureUnits, ShouldEqual, "F")
 	})
 
+	Convey("Should get Vehicle config", t, func() {
+		vehicles, err := client.Vehicles()
+		vehicle := vehicles[0]
+		config, err := vehicle.VehicleConfig()
+		So(err, ShouldBeNil)
+		So(config.CanAcceptNavigationRequests, ShouldBeTrue)
+		So(config.TrimBadging, ShouldEqual, "p90d")
+	})
+
 	Convey("Should get Vehicle state", t, func() {
 		vehicles, err := client.Vehicles()
 		vehicle := vehicles[0]
@@ -88,6 +100,37 @@ func TestStatesSpec(t *testing.T) {
 		So(status.Rt, ShouldEqual, 0)
 	})
 
+	Convey("Should get all vehicle data", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		vehicleData, err := vehicle.VehicleData()
+		So(err, ShouldBeNil)
+		So(vehicleData.Vin, ShouldEqual, "abc123")
+		So(vehicleData.ChargeState.BatteryLevel, ShouldEqual, 80)
+		So(vehicleData.ChargeState.Timestamp, ShouldEqual, 1543186971731)
+		So(vehicleData.ClimateState.InsideTemp, ShouldEqual, 21.5)
+		So(vehicleData.DriveState.Latitude, ShouldEqual, 0)
+		So(vehicleData.GuiSettings.GuiDistanceUnits, ShouldEqual, "mi/hr")
+		So(vehicleData.VehicleConfig.CarType, ShouldEqual, "models2")
+		So(vehicleData.VehicleState.Locked, ShouldBeTrue)
+	})
+
+	Convey("Should get selected vehicle data without waking the vehicle", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		vehicleData, err := vehicle.VehicleDataWith(ChargeStateEndpoint, LocationDataEndpoint, LetSleep)
+		So(err, ShouldBeNil)
+		So(vehicleData.ChargeState.BatteryLevel, ShouldEqual, 80)
+		So(vehicleData.DriveState.Latitude, ShouldEqual, 35.1)
+		So(vehicleData.DriveState.Longitude, ShouldEqual, 20.2)
+		So(vehicleData.ClimateState, ShouldBeNil)
+		So(vehicleData.GuiSettings, ShouldBeNil)
+		So(vehicleData.VehicleConfig, ShouldBeNil)
+		So(vehicleData.VehicleState, ShouldBeNil)
+	})
+
 	AuthURL = previousAuthURL
 	BaseURL = previousURL
 }
@@ -2,6 +2,7 @@ package tesla
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -28,7 +29,7 @@ type StreamEventResponse struct {
 	Heading                                 float64       `json:"heading"`
 	Latitude                                float64       `json:"latitude"`
 	Longitude                               float64       `json:"longitude"`
-	ShiftState                              string        `json:"shift_state"`
+	ShiftState                              ShiftState    `json:"shift_state"`
 	Speed                                   float64       `json:"speed"`
 	AutoparkState                           string        `json:"autopark_state"`
 	AutoparkStateReason                     string        `json:"autopark_state_reason"`
@@ -46,41 +47,58 @@ type StreamEventResponse struct {
 
 // Stream requests a stream from the vehicle and returns a Go channel
 func (v Vehicle) Stream() (chan *StreamEventResponse, chan error, error) {
+	client := v.apiClient()
+	t := v.telemetry()
+	ctx, span := v.startSpan(context.Background(), "tesla.stream")
 	url := StreamingURL + "/connect/" + strconv.Itoa(v.VehicleID)
-	req, _ := http.NewRequest("GET", url, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
 	req.Header.Set("Upgrade", "websocket")
 	req.Header.Set("Sec-Websocket-Version", "13")
 	req.Header.Set("Sec-WebSocket-Key", "SGVsbG8sIHevcmxkIQ==")
-	req.SetBasicAuth(ActiveClient.Auth.Email, v.Tokens[0])
-	resp, err := ActiveClient.HTTP.Do(req)
+	req.SetBasicAuth(client.Auth.Email, v.Tokens[0])
+	resp, err := client.roundTrip(req)
 
 	if err != nil {
+		endStream(ctx, t, span, err)
 		return nil, nil, err
 	}
 
 	eventChan := make(chan *StreamEventResponse)
 	errChan := make(chan error)
-	go readStream(resp, eventChan, errChan)
+	go func() {
+		err := readStream(ctx, t, resp, eventChan, errChan)
+		endStream(ctx, t, span, err)
+	}()
 
 	return eventChan, errChan, nil
 }
 
-// readStream reads the stream itself from the vehicle
-func readStream(resp *http.Response, eventChan chan *StreamEventResponse, errChan chan error) {
+// readStream reads the stream itself from the vehicle, returning the first error that
+// interrupted it, if any
+func readStream(ctx context.Context, t *Telemetry, resp *http.Response, eventChan chan *StreamEventResponse, errChan chan error) error {
 	reader := bufio.NewReader(resp.Body)
 	scanner := bufio.NewScanner(reader)
 	scanner.Split(bufio.ScanLines)
 	defer resp.Body.Close()
 
+	var streamErr error
 	for scanner.Scan() {
 		streamEvent, err := parseStreamEvent(scanner.Text())
 		if err == nil {
+			observeStreamSample(ctx, t)
 			eventChan <- streamEvent
 		} else {
+			if streamErr == nil {
+				streamErr = err
+			}
 			errChan <- err
 		}
 	}
+	if err := scanner.Err(); err != nil && streamErr == nil {
+		streamErr = err
+	}
 	errChan <- errors.New("HTTP stream closed")
+	return streamErr
 }
 
 // parseStreamEvent parses the stream event, setting all of the appropriate data types
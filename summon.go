@@ -0,0 +1,355 @@
+package tesla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// SummonState is the state of an autopark or Smart Summon session as reported by the vehicle
+type SummonState string
+
+// Summon states reported by the vehicle
+const (
+	SummonStandby     SummonState = "standby"
+	SummonReady       SummonState = "ready"
+	SummonActive      SummonState = "active"
+	SummonPaused      SummonState = "paused"
+	SummonAborting    SummonState = "aborting"
+	SummonAborted     SummonState = "aborted"
+	SummonComplete    SummonState = "complete"
+	SummonUnavailable SummonState = "unavailable"
+)
+
+// Summon abort reasons raised by the session itself, rather than the vehicle
+const (
+	SummonAbortHeartbeatMissed = "heartbeat_missed"
+	SummonAbortCanceled        = "canceled"
+	SummonAbortStopped         = "stopped"
+	SummonAbortDisconnected    = "disconnected"
+)
+
+var (
+	// ErrNoStreamingToken is returned when the vehicle has no token to authenticate the stream with
+	ErrNoStreamingToken = errors.New("vehicle has no streaming token")
+	// ErrSummonHelloTimeout is returned when the vehicle doesn't greet the autopark channel within SummonHelloTimeout
+	ErrSummonHelloTimeout = errors.New("vehicle did not greet the autopark channel before the timeout")
+	// ErrSummonAborted is returned when the vehicle aborts a session
+	ErrSummonAborted = errors.New("vehicle aborted the session")
+)
+
+// SummonHelloTimeout is how long Summon waits for the vehicle to greet the autopark channel
+var SummonHelloTimeout = 10 * time.Second
+
+// SummonConn is the autopark channel of the vehicle's streaming websocket. Close must unblock a
+// pending ReadJSON
+type SummonConn interface {
+	ReadJSON(v interface{}) error
+	WriteJSON(v interface{}) error
+	Close() error
+}
+
+// SummonDialer opens the autopark channel for a vehicle
+var SummonDialer = dialSummon
+
+// readDeadliner is implemented by connections that support read deadlines, such as websockets
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// SummonTransition records the session moving from one state to another
+type SummonTransition struct {
+	From   SummonState
+	To     SummonState
+	At     time.Time
+	Reason string
+}
+
+// SummonSession is an open autopark channel to the vehicle. While the caller keeps calling
+// Heartbeat, the session sends heartbeats to the vehicle at the frequency it advertised. Once
+// the caller stops heartbeating, its context is canceled or Stop is called, the session sends
+// an abort and closes the channel. The session also ends if the vehicle aborts it, or a heartbeat
+// can't be sent
+type SummonSession struct {
+	*Maneuver
+	vehicle     Vehicle
+	conn        SummonConn
+	writeMu     sync.Mutex
+	driveState  *DriveState
+	transitions chan SummonTransition
+	mu          sync.Mutex
+	state       SummonState
+	abortReason string
+}
+
+// summonMessage is a message sent to the vehicle over the autopark channel
+type summonMessage struct {
+	MsgType   string  `json:"msg_type"`
+	Timestamp int64   `json:"timestamp,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+// Summon opens a Smart Summon session with the vehicle. The vehicle must be in park, and the
+// heartbeat requirements advertised by the vehicle override those of the supplied policy.
+// A nil policy uses DefaultSafetyPolicy
+func (v Vehicle) Summon(ctx context.Context, capability *MotionCapability, policy *SafetyPolicy) (*SummonSession, error) {
+	// The session outlives this call, so it keeps the client it was started with
+	v.client = v.apiClient()
+	if policy == nil {
+		policy = DefaultSafetyPolicy
+	}
+	driveState, err := v.checkParked(capability, policy)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := SummonDialer(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	hello, err := readHello(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if hello.Autopark.HeartbeatFrequency > 0 {
+		policy = SafetyPolicyFromStream(hello, policy.MaxDriveStateAge)
+	}
+	session := &SummonSession{
+		vehicle:     v,
+		conn:        conn,
+		driveState:  driveState,
+		transitions: make(chan SummonTransition, 32),
+		state:       SummonStandby,
+	}
+	session.Maneuver = newManeuver(ctx, policy.heartbeatTimeout(), session.abort)
+	go session.sendHeartbeats(policy.HeartbeatFrequency)
+	go session.readStatus()
+	return session, nil
+}
+
+// readHello waits for the vehicle to greet the autopark channel, advertising its heartbeat
+// requirements. The channel is closed if the context is done, or SummonHelloTimeout passes, first
+func readHello(ctx context.Context, conn SummonConn) (*StreamEventResponse, error) {
+	helloCtx, cancel := context.WithTimeout(ctx, SummonHelloTimeout)
+	defer cancel()
+	deadliner, canDeadline := conn.(readDeadliner)
+	if deadline, ok := helloCtx.Deadline(); ok && canDeadline {
+		deadliner.SetReadDeadline(deadline)
+	}
+	hello := &StreamEventResponse{}
+	read := make(chan error, 1)
+	go func() {
+		for hello.MsgType != "control:hello" {
+			if err := conn.ReadJSON(hello); err != nil {
+				read <- err
+				return
+			}
+		}
+		read <- nil
+	}()
+	select {
+	case err := <-read:
+		if err != nil {
+			conn.Close()
+			if deadline, ok := helloCtx.Deadline(); helloCtx.Err() != nil || ok && !time.Now().Before(deadline) {
+				return nil, helloError(ctx)
+			}
+			return nil, err
+		}
+		if canDeadline {
+			deadliner.SetReadDeadline(time.Time{})
+		}
+		return hello, nil
+	case <-helloCtx.Done():
+		conn.Close()
+		<-read
+		return nil, helloError(ctx)
+	}
+}
+
+// helloError returns the context's error if it is done, or ErrSummonHelloTimeout
+func helloError(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return ErrSummonHelloTimeout
+}
+
+// Forward commands the vehicle to pull forward
+func (s *SummonSession) Forward() error {
+	return s.command(summonMessage{MsgType: "autopark:cmd_forward", Latitude: s.driveState.Latitude, Longitude: s.driveState.Longitude})
+}
+
+// Reverse commands the vehicle to go in reverse
+func (s *SummonSession) Reverse() error {
+	return s.command(summonMessage{MsgType: "autopark:cmd_reverse", Latitude: s.driveState.Latitude, Longitude: s.driveState.Longitude})
+}
+
+// SmartSummon commands the vehicle to drive itself to the supplied location
+func (s *SummonSession) SmartSummon(lat, lon float64) error {
+	return s.command(summonMessage{MsgType: "autopark:cmd_smart_summon", Latitude: lat, Longitude: lon})
+}
+
+// State returns the last state reported by the vehicle
+func (s *SummonSession) State() SummonState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// AbortReason returns why the session was aborted, either as reported by the vehicle
+// or one of the SummonAbort reasons, or an empty string if it hasn't been aborted
+func (s *SummonSession) AbortReason() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.abortReason
+}
+
+// Transitions returns a channel of the session's state transitions, which is closed once the
+// session ends. Transitions are dropped if the channel isn't drained
+func (s *SummonSession) Transitions() <-chan SummonTransition {
+	return s.transitions
+}
+
+// command sends a command to the vehicle, recording it in the audit log and telemetry like the
+// commands sent through the API
+func (s *SummonSession) command(message summonMessage) error {
+	ctx, span := s.vehicle.startCommandSpan(context.Background(), message.MsgType)
+	defer span.End()
+	start := time.Now()
+	err := s.send(message)
+	payload, _ := json.Marshal(message)
+	s.vehicle.audit(message.MsgType, payload, start, nil, err)
+	s.vehicle.observeCommand(ctx, span, message.MsgType, start, nil, err)
+	return err
+}
+
+// send writes a message to the vehicle, serializing writes to the websocket
+func (s *SummonSession) send(message summonMessage) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteJSON(message)
+}
+
+// sendHeartbeats sends heartbeats to the vehicle until the session ends, ending it if a heartbeat
+// can't be sent
+func (s *SummonSession) sendHeartbeats(frequency time.Duration) {
+	if frequency <= 0 {
+		frequency = DefaultSafetyPolicy.HeartbeatFrequency
+	}
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := s.send(summonMessage{MsgType: "autopark:heartbeat_app", Timestamp: time.Now().UnixNano() / int64(time.Millisecond)})
+			if err != nil {
+				s.setAbortReason(SummonAbortDisconnected)
+				s.halt(err)
+				return
+			}
+		case <-s.Done():
+			return
+		}
+	}
+}
+
+// readStatus reads status messages from the vehicle, recording state transitions, until the
+// channel closes or the vehicle aborts the session
+func (s *SummonSession) readStatus() {
+	defer close(s.transitions)
+	for {
+		event := &StreamEventResponse{}
+		err := s.conn.ReadJSON(event)
+		if err != nil {
+			s.setAbortReason(SummonAbortDisconnected)
+			s.halt(err)
+			return
+		}
+		state := SummonState(event.SmartSummonState)
+		if state == "" {
+			state = SummonState(event.AutoparkState)
+		}
+		if state == "" {
+			continue
+		}
+		reason := event.SmartSummonLastAbortReason
+		if reason == "" {
+			reason = event.SmartSummonLastSafetyMonitorAbortReason
+		}
+		if reason == "" {
+			reason = event.AutoparkStateReason
+		}
+		s.transition(state, reason)
+		if state == SummonAborted {
+			s.halt(ErrSummonAborted)
+			return
+		}
+	}
+}
+
+// transition moves the session to a new state
+func (s *SummonSession) transition(state SummonState, reason string) {
+	s.mu.Lock()
+	from := s.state
+	if from == state {
+		s.mu.Unlock()
+		return
+	}
+	s.state = state
+	if (state == SummonAborting || state == SummonAborted) && s.abortReason == "" {
+		s.abortReason = reason
+	}
+	s.mu.Unlock()
+	select {
+	case s.transitions <- SummonTransition{From: from, To: state, At: time.Now(), Reason: reason}:
+	default:
+	}
+}
+
+// setAbortReason records why the session was aborted, keeping the first reason
+func (s *SummonSession) setAbortReason(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.abortReason == "" {
+		s.abortReason = reason
+	}
+}
+
+// abort sends the abort to the vehicle and closes the channel
+func (s *SummonSession) abort(reason error) error {
+	switch reason {
+	case nil:
+		s.setAbortReason(SummonAbortStopped)
+	case ErrHeartbeatMissed:
+		s.setAbortReason(SummonAbortHeartbeatMissed)
+	case context.Canceled, context.DeadlineExceeded:
+		s.setAbortReason(SummonAbortCanceled)
+	}
+	err := s.command(summonMessage{MsgType: "autopark:cmd_abort"})
+	s.conn.Close()
+	return err
+}
+
+// dialSummon opens the vehicle's streaming websocket, authenticating with the vehicle's streaming token
+func dialSummon(ctx context.Context, v Vehicle) (SummonConn, error) {
+	if len(v.Tokens) == 0 {
+		return nil, ErrNoStreamingToken
+	}
+	url := StreamingURL + "/connect/" + strconv.Itoa(v.VehicleID)
+	url = strings.Replace(strings.Replace(url, "https://", "wss://", 1), "http://", "ws://", 1)
+	req, _ := http.NewRequest("GET", url, nil)
+	req.SetBasicAuth(v.apiClient().Auth.Email, v.Tokens[0])
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, req.Header)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
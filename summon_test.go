@@ -0,0 +1,225 @@
+package tesla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	SummonHelloJSON         = `{"msg_type":"control:hello","connection_timeout":30000,"autopark":{"heartbeat_frequency":20,"autopark_pause_timeout":100,"autopark_stop_timeout":2000}}`
+	SummonActiveJSON        = `{"msg_type":"autopark:status","autopark_state":"active"}`
+	SummonObstacleAbortJSON = `{"msg_type":"autopark:status","autopark_state":"aborted","autopark_state_reason":"obstacle_detected"}`
+)
+
+// fakeSummonConn greets the session if hello is set, then blocks reads until it is closed.
+// Writes fail with writeErr
+type fakeSummonConn struct {
+	hello     bool
+	writeErr  error
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newFakeSummonConn(hello bool, writeErr error) *fakeSummonConn {
+	return &fakeSummonConn{hello: hello, writeErr: writeErr, closed: make(chan struct{})}
+}
+
+func (c *fakeSummonConn) ReadJSON(v interface{}) error {
+	if c.hello {
+		c.hello = false
+		return json.Unmarshal([]byte(SummonHelloJSON), v)
+	}
+	<-c.closed
+	return errors.New("closed")
+}
+
+func (c *fakeSummonConn) WriteJSON(v interface{}) error {
+	return c.writeErr
+}
+
+func (c *fakeSummonConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// serveSummon serves the autopark channel, reporting the message types received from the client
+func serveSummon(t *testing.T, received chan string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		email, token, _ := req.BasicAuth()
+		if req.URL.Path != "/connect/456" || email != "elon@tesla.com" || token != "1" {
+			w.WriteHeader(401)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(SummonHelloJSON))
+		for {
+			message := &summonMessage{}
+			if err := conn.ReadJSON(message); err != nil {
+				return
+			}
+			select {
+			case received <- message.MsgType:
+			default:
+			}
+			switch message.MsgType {
+			case "autopark:cmd_forward":
+				conn.WriteMessage(websocket.TextMessage, []byte(SummonActiveJSON))
+			case "autopark:cmd_reverse":
+				conn.WriteMessage(websocket.TextMessage, []byte(SummonObstacleAbortJSON))
+			}
+		}
+	}))
+}
+
+func TestSummonSpec(t *testing.T) {
+	_, client := serveAPI(t)
+	received := make(chan string, 1024)
+	ws := serveSummon(t, received)
+	defer ws.Close()
+	previousStreamingURL := StreamingURL
+	StreamingURL = ws.URL
+
+	capability, _ := GrantMotionCapability(MotionAcknowledgement)
+	vehicle := &Vehicle{ID: 5678, VehicleID: 456, Tokens: []string{"1", "2"}}
+
+	Convey("Should require a motion capability", t, func() {
+		_, err := vehicle.Summon(context.Background(), nil, nil)
+		So(err, ShouldEqual, ErrMotionCapabilityRequired)
+	})
+
+	Convey("Should heartbeat and report transitions until stopped", t, func() {
+		session, err := vehicle.Summon(context.Background(), capability, nil)
+		So(err, ShouldBeNil)
+		So(session.State(), ShouldEqual, SummonStandby)
+		So(session.Forward(), ShouldBeNil)
+		for i := 0; i < 10; i++ {
+			session.Heartbeat()
+			time.Sleep(10 * time.Millisecond)
+		}
+		transition := <-session.Transitions()
+		So(transition.From, ShouldEqual, SummonStandby)
+		So(transition.To, ShouldEqual, SummonActive)
+		So(session.Stop(), ShouldBeNil)
+		So(session.AbortReason(), ShouldEqual, SummonAbortStopped)
+
+		messages := map[string]bool{}
+		timeout := time.After(time.Second)
+		timedOut := false
+		for !messages["autopark:cmd_abort"] && !timedOut {
+			select {
+			case message := <-received:
+				messages[message] = true
+			case <-timeout:
+				timedOut = true
+			}
+		}
+		So(messages["autopark:cmd_abort"], ShouldBeTrue)
+		So(messages["autopark:cmd_forward"], ShouldBeTrue)
+		So(messages["autopark:heartbeat_app"], ShouldBeTrue)
+	})
+
+	Convey("Should abort when the caller stops heartbeating", t, func() {
+		session, err := vehicle.Summon(context.Background(), capability, nil)
+		So(err, ShouldBeNil)
+		<-session.Done()
+		So(session.Err(), ShouldEqual, ErrHeartbeatMissed)
+		So(session.AbortReason(), ShouldEqual, SummonAbortHeartbeatMissed)
+	})
+
+	Convey("Should abort when the context is canceled", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		session, err := vehicle.Summon(ctx, capability, nil)
+		So(err, ShouldBeNil)
+		cancel()
+		<-session.Done()
+		So(session.Err(), ShouldEqual, context.Canceled)
+		So(session.AbortReason(), ShouldEqual, SummonAbortCanceled)
+	})
+
+	Convey("Should report the vehicle's abort reason", t, func() {
+		session, err := vehicle.Summon(context.Background(), capability, nil)
+		So(err, ShouldBeNil)
+		So(session.Reverse(), ShouldBeNil)
+		session.Heartbeat()
+		transition := <-session.Transitions()
+		So(transition.To, ShouldEqual, SummonAborted)
+		So(transition.Reason, ShouldEqual, "obstacle_detected")
+		<-session.Done()
+		So(session.Err(), ShouldEqual, ErrSummonAborted)
+		So(session.AbortReason(), ShouldEqual, "obstacle_detected")
+	})
+
+	Convey("Should audit commands sent over the autopark channel", t, func() {
+		records := []*AuditRecord{}
+		var mu sync.Mutex
+		client.Audit = AuditFunc(func(record *AuditRecord) {
+			mu.Lock()
+			defer mu.Unlock()
+			records = append(records, record)
+		})
+		defer func() { client.Audit = nil }()
+		session, err := vehicle.Summon(context.Background(), capability, nil)
+		So(err, ShouldBeNil)
+		So(session.Forward(), ShouldBeNil)
+		So(session.Stop(), ShouldBeNil)
+		mu.Lock()
+		defer mu.Unlock()
+		So(records, ShouldHaveLength, 2)
+		So(records[0].Command, ShouldEqual, "autopark:cmd_forward")
+		So(records[0].VehicleID, ShouldEqual, 5678)
+		So(records[1].Command, ShouldEqual, "autopark:cmd_abort")
+	})
+
+	Convey("Should give up when the vehicle doesn't greet the channel", t, func() {
+		previousDialer := SummonDialer
+		defer func() { SummonDialer = previousDialer }()
+		SummonDialer = func(ctx context.Context, v Vehicle) (SummonConn, error) {
+			return newFakeSummonConn(false, nil), nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := vehicle.Summon(ctx, capability, nil)
+		So(err, ShouldResemble, context.DeadlineExceeded)
+
+		previousHelloTimeout := SummonHelloTimeout
+		defer func() { SummonHelloTimeout = previousHelloTimeout }()
+		SummonHelloTimeout = 20 * time.Millisecond
+		_, err = vehicle.Summon(context.Background(), capability, nil)
+		So(err, ShouldEqual, ErrSummonHelloTimeout)
+	})
+
+	Convey("Should end the session when a heartbeat can't be sent", t, func() {
+		previousDialer := SummonDialer
+		defer func() { SummonDialer = previousDialer }()
+		writeErr := errors.New("broken pipe")
+		SummonDialer = func(ctx context.Context, v Vehicle) (SummonConn, error) {
+			return newFakeSummonConn(true, writeErr), nil
+		}
+		session, err := vehicle.Summon(context.Background(), capability, &SafetyPolicy{MaxDriveStateAge: time.Minute, HeartbeatTimeout: time.Minute})
+		So(err, ShouldBeNil)
+		<-session.Done()
+		So(session.Err(), ShouldEqual, writeErr)
+		So(session.AbortReason(), ShouldEqual, SummonAbortDisconnected)
+	})
+
+	Convey("Should require a streaming token", t, func() {
+		_, err := (&Vehicle{ID: 5678}).Summon(context.Background(), capability, nil)
+		So(err, ShouldEqual, ErrNoStreamingToken)
+	})
+
+	StreamingURL = previousStreamingURL
+}
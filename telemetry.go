@@ -0,0 +1,246 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter the library reports telemetry with
+const InstrumentationName = "github.com/rdbell/tesla"
+
+var (
+	numericPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Telemetry reports spans and metrics for API requests, commands, wake-ups and stream sessions
+// through the OpenTelemetry API
+type Telemetry struct {
+	tracer          trace.Tracer
+	requestDuration metric.Float64Histogram
+	commandDuration metric.Float64Histogram
+	errors          metric.Int64Counter
+	streamSamples   metric.Int64Counter
+}
+
+// NewTelemetry creates the library's instruments with the supplied providers. Nil providers
+// use the global OpenTelemetry providers
+func NewTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Telemetry, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	meter := meterProvider.Meter(InstrumentationName)
+	telemetry := &Telemetry{tracer: tracerProvider.Tracer(InstrumentationName)}
+	var err error
+	telemetry.requestDuration, err = meter.Float64Histogram("tesla.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of Tesla API requests"))
+	if err != nil {
+		return nil, err
+	}
+	telemetry.commandDuration, err = meter.Float64Histogram("tesla.command.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of vehicle commands"))
+	if err != nil {
+		return nil, err
+	}
+	telemetry.errors, err = meter.Int64Counter("tesla.errors",
+		metric.WithUnit("{error}"), metric.WithDescription("Failed requests, commands, wake-ups and streams, by error type"))
+	if err != nil {
+		return nil, err
+	}
+	telemetry.streamSamples, err = meter.Int64Counter("tesla.stream.samples",
+		metric.WithUnit("{sample}"), metric.WithDescription("Samples received from vehicle streams"))
+	if err != nil {
+		return nil, err
+	}
+	return telemetry, nil
+}
+
+// Instrument reports the client's telemetry with the supplied providers, adding an interceptor
+// that traces and times every API request. Nil providers use the global OpenTelemetry providers
+func (c *Client) Instrument(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) error {
+	telemetry, err := NewTelemetry(tracerProvider, meterProvider)
+	if err != nil {
+		return err
+	}
+	c.Telemetry = telemetry
+	c.Use(telemetry.Interceptor())
+	return nil
+}
+
+// Interceptor traces and times every API request
+func (t *Telemetry) Interceptor() Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		endpoint := endpointOf(req.URL)
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("tesla.endpoint", endpoint),
+		}
+		ctx, span := t.tracer.Start(req.Context(), req.Method+" "+endpoint,
+			trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+		if id, ok := vehicleIDOf(req.URL); ok {
+			span.SetAttributes(attribute.Int64("tesla.vehicle_id", id))
+		}
+		start := time.Now()
+		res, err := next(req.WithContext(ctx))
+		if err == nil {
+			status := attribute.Int("http.response.status_code", res.StatusCode)
+			span.SetAttributes(status)
+			attrs = append(attrs, status)
+		}
+		switch {
+		case err != nil:
+			attrs = append(attrs, t.recordError(ctx, span, "request", errorTypeOf(err), err))
+		case res.StatusCode != http.StatusOK:
+			attrs = append(attrs, t.recordError(ctx, span, "request", strconv.Itoa(res.StatusCode), &StatusError{StatusCode: res.StatusCode, Status: res.Status}))
+		}
+		t.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		return res, err
+	}
+}
+
+// recordError marks the span as failed and counts the error, returning its error type attribute
+func (t *Telemetry) recordError(ctx context.Context, span trace.Span, operation, errorType string, err error) attribute.KeyValue {
+	errorTypeAttr := attribute.String("error.type", errorType)
+	span.SetAttributes(errorTypeAttr)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, Redact(err.Error()))
+	t.errors.Add(ctx, 1, metric.WithAttributes(attribute.String("tesla.operation", operation), errorTypeAttr))
+	return errorTypeAttr
+}
+
+// telemetry returns the telemetry of the vehicle's client, or nil if it isn't instrumented
+func (v Vehicle) telemetry() *Telemetry {
+	client := v.apiClient()
+	if client == nil {
+		return nil
+	}
+	return client.Telemetry
+}
+
+// startSpan starts a span for an operation on the vehicle, if its client is instrumented
+func (v Vehicle) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	t := v.telemetry()
+	if t == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	attrs = append(attrs, attribute.Int64("tesla.vehicle_id", v.ID))
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// startCommandSpan starts a span for a command sent to the vehicle
+func (v Vehicle) startCommandSpan(ctx context.Context, command string) (context.Context, trace.Span) {
+	return v.startSpan(ctx, "tesla.command "+command, attribute.String("tesla.command", command))
+}
+
+// observeCommand records the outcome of a command on its span and metrics
+func (v Vehicle) observeCommand(ctx context.Context, span trace.Span, command string, start time.Time, response *CommandResponse, err error) {
+	t := v.telemetry()
+	if t == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{attribute.String("tesla.command", command)}
+	if response != nil {
+		span.SetAttributes(attribute.Bool("tesla.result", response.Response.Result))
+		if response.Response.Reason != "" {
+			span.SetAttributes(attribute.String("tesla.reason", response.Response.Reason))
+		}
+	}
+	switch {
+	case err != nil:
+		attrs = append(attrs, t.recordError(ctx, span, "command", errorTypeOf(err), err))
+	case response != nil && !response.Response.Result && response.Response.Reason != "":
+		attrs = append(attrs, t.recordError(ctx, span, "command", response.Response.Reason, errors.New(response.Response.Reason)))
+	}
+	t.commandDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// observeWakeup records the outcome of waking the vehicle on its span
+func (v Vehicle) observeWakeup(ctx context.Context, span trace.Span, attempts int, err error) {
+	t := v.telemetry()
+	if t == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("tesla.retry_count", attempts-1))
+	if err != nil {
+		t.recordError(ctx, span, "wakeup", errorTypeOf(err), err)
+	}
+}
+
+// endStream records the outcome of a stream session and ends its span. The telemetry is
+// captured when the stream starts, as the session outlives the call to Stream
+func endStream(ctx context.Context, t *Telemetry, span trace.Span, err error) {
+	if t != nil && err != nil {
+		t.recordError(ctx, span, "stream", errorTypeOf(err), err)
+	}
+	span.End()
+}
+
+// observeStreamSample counts a sample received from a vehicle stream
+func observeStreamSample(ctx context.Context, t *Telemetry) {
+	if t != nil {
+		t.streamSamples.Add(ctx, 1)
+		trace.SpanFromContext(ctx).AddEvent("sample")
+	}
+}
+
+// errorTypeOf classifies an error for the error.type attribute
+func errorTypeOf(err error) string {
+	var urlErr *url.Error
+	var statusErr *StatusError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case err == ErrWakeTimeout:
+		return "wake_timeout"
+	case errors.As(err, &statusErr):
+		return strconv.Itoa(statusErr.StatusCode)
+	case errors.As(err, &urlErr):
+		return "transport"
+	}
+	return "_OTHER"
+}
+
+// endpointOf returns the API path of a request relative to BaseURL, with IDs replaced by {id}
+// to keep the endpoint's cardinality low
+func endpointOf(u *url.URL) string {
+	path := u.Path
+	if base, err := url.Parse(BaseURL); err == nil && u.Host == base.Host {
+		path = strings.TrimPrefix(path, base.Path)
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if numericPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// vehicleIDOf returns the vehicle ID of a request to a vehicle's endpoints
+func vehicleIDOf(u *url.URL) (int64, bool) {
+	segments := strings.Split(u.Path, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "vehicles" {
+			id, err := strconv.ParseInt(segments[i+1], 10, 64)
+			return id, err == nil
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,193 @@
+package tesla
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetrySpec(t *testing.T) {
+	_, client := serveAPI(t)
+	previousWakePollInterval := WakePollInterval
+	WakePollInterval = 10 * time.Millisecond
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	err := client.Instrument(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)), sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spanNamed := func(name string) sdktrace.ReadOnlySpan {
+		for _, span := range spans.Ended() {
+			if span.Name() == name {
+				return span
+			}
+		}
+		return nil
+	}
+	attributes := func(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+		attrs := map[attribute.Key]attribute.Value{}
+		for _, attr := range span.Attributes() {
+			attrs[attr.Key] = attr.Value
+		}
+		return attrs
+	}
+	collect := func() map[string]metricdata.Aggregation {
+		rm := metricdata.ResourceMetrics{}
+		So(reader.Collect(context.Background(), &rm), ShouldBeNil)
+		metrics := map[string]metricdata.Aggregation{}
+		for _, scope := range rm.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				metrics[m.Name] = m.Data
+			}
+		}
+		return metrics
+	}
+	errorCount := func(operation, errorType string) int64 {
+		sum, _ := collect()["tesla.errors"].(metricdata.Sum[int64])
+		for _, point := range sum.DataPoints {
+			op, _ := point.Attributes.Value("tesla.operation")
+			et, _ := point.Attributes.Value("error.type")
+			if op.AsString() == operation && et.AsString() == errorType {
+				return point.Value
+			}
+		}
+		return 0
+	}
+
+	Convey("Should trace API requests", t, func() {
+		_, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		span := spanNamed("GET /vehicles")
+		So(span, ShouldNotBeNil)
+		attrs := attributes(span)
+		So(attrs["tesla.endpoint"].AsString(), ShouldEqual, "/vehicles")
+		So(attrs["http.response.status_code"].AsInt64(), ShouldEqual, 200)
+	})
+
+	Convey("Should trace commands with their requests as children", t, func() {
+		vehicle := Vehicle{ID: 1234}
+		So(vehicle.StartCharging().Error(), ShouldEqual, "complete")
+		command := spanNamed("tesla.command charge_start")
+		So(command, ShouldNotBeNil)
+		attrs := attributes(command)
+		So(attrs["tesla.vehicle_id"].AsInt64(), ShouldEqual, 1234)
+		So(attrs["tesla.result"].AsBool(), ShouldBeFalse)
+		So(attrs["tesla.reason"].AsString(), ShouldEqual, "complete")
+		So(attrs["error.type"].AsString(), ShouldEqual, "complete")
+
+		request := spanNamed("POST /vehicles/{id}/command/charge_start")
+		So(request, ShouldNotBeNil)
+		So(request.Parent().SpanID(), ShouldEqual, command.SpanContext().SpanID())
+		So(attributes(request)["tesla.vehicle_id"].AsInt64(), ShouldEqual, 1234)
+		So(errorCount("command", "complete"), ShouldEqual, 1)
+	})
+
+	Convey("Should count errors by type", t, func() {
+		err := Vehicle{ID: 9999}.FlashLights()
+		So(err, ShouldNotBeNil)
+		So(errorCount("request", "404"), ShouldEqual, 1)
+		So(errorCount("command", "404"), ShouldEqual, 1)
+		_, err = Vehicle{ID: 1234}.Command(context.Background(), "remote_auto_steering_wheel_heat_climate_request", nil)
+		So(err, ShouldNotBeNil)
+		So(errorCount("command", "not_supported"), ShouldEqual, 1)
+	})
+
+	Convey("Should record request and command latency", t, func() {
+		So(Vehicle{ID: 1234}.HonkHorn(), ShouldBeNil)
+		metrics := collect()
+		requests, ok := metrics["tesla.request.duration"].(metricdata.Histogram[float64])
+		So(ok, ShouldBeTrue)
+		So(len(requests.DataPoints), ShouldBeGreaterThan, 0)
+		commands, ok := metrics["tesla.command.duration"].(metricdata.Histogram[float64])
+		So(ok, ShouldBeTrue)
+		found := false
+		for _, point := range commands.DataPoints {
+			command, _ := point.Attributes.Value("tesla.command")
+			if command.AsString() == "honk_horn" {
+				found = point.Count == 1
+			}
+		}
+		So(found, ShouldBeTrue)
+	})
+
+	Convey("Should trace wake-ups with their retry count", t, func() {
+		_, err := Vehicle{ID: 2468}.WakeupAndWait(context.Background(), 35*time.Millisecond)
+		So(err, ShouldEqual, ErrWakeTimeout)
+		span := spanNamed("tesla.wakeup")
+		So(span, ShouldNotBeNil)
+		So(attributes(span)["tesla.retry_count"].AsInt64(), ShouldBeGreaterThan, 0)
+		So(attributes(span)["error.type"].AsString(), ShouldEqual, "wake_timeout")
+		So(errorCount("wakeup", "wake_timeout"), ShouldEqual, 1)
+	})
+
+	Convey("Should trace stream sessions and count their samples", t, func() {
+		stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(StreamEventString + "\n" + StreamEventString + "\n"))
+		}))
+		defer stream.Close()
+		previousStreamingURL := StreamingURL
+		StreamingURL = stream.URL
+		defer func() { StreamingURL = previousStreamingURL }()
+
+		vehicle := Vehicle{ID: 1234, VehicleID: 123, Tokens: []string{"456"}}
+		eventChan, errChan, err := vehicle.Stream()
+		So(err, ShouldBeNil)
+		<-eventChan
+		<-eventChan
+		<-errChan
+		for i := 0; i < 100 && spanNamed("tesla.stream") == nil; i++ {
+			time.Sleep(time.Millisecond)
+		}
+		span := spanNamed("tesla.stream")
+		So(span, ShouldNotBeNil)
+		So(span.Events(), ShouldHaveLength, 2)
+		samples, ok := collect()["tesla.stream.samples"].(metricdata.Sum[int64])
+		So(ok, ShouldBeTrue)
+		So(samples.DataPoints[0].Value, ShouldEqual, 2)
+		So(spanNamed("GET /connect/{id}").Parent().SpanID(), ShouldEqual, span.SpanContext().SpanID())
+	})
+
+	Convey("Should record the error that interrupted a stream", t, func() {
+		stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(StreamEventString + "\n"))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}))
+		defer stream.Close()
+		previousStreamingURL := StreamingURL
+		StreamingURL = stream.URL
+		defer func() { StreamingURL = previousStreamingURL }()
+
+		vehicle := Vehicle{ID: 1357, VehicleID: 123, Tokens: []string{"456"}}
+		eventChan, errChan, err := vehicle.Stream()
+		So(err, ShouldBeNil)
+		<-eventChan
+		<-errChan
+		var span sdktrace.ReadOnlySpan
+		for i := 0; i < 100 && span == nil; i++ {
+			for _, ended := range spans.Ended() {
+				if ended.Name() == "tesla.stream" && attributes(ended)["tesla.vehicle_id"].AsInt64() == 1357 {
+					span = ended
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+		So(span, ShouldNotBeNil)
+		So(span.Status().Code, ShouldEqual, codes.Error)
+		So(errorCount("stream", "_OTHER"), ShouldEqual, 1)
+	})
+
+	WakePollInterval = previousWakePollInterval
+}
@@ -0,0 +1,189 @@
+package tesla
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrStaleData is returned when vehicle data is older than the caller allows
+var ErrStaleData = errors.New("vehicle data is stale")
+
+// epochTime converts a Unix timestamp the API reported in seconds or milliseconds, or the zero
+// time if it is unset
+func epochTime(epoch int64) time.Time {
+	switch {
+	case epoch <= 0:
+		return time.Time{}
+	case epoch > 1e12:
+		return time.Unix(0, epoch*int64(time.Millisecond))
+	}
+	return time.Unix(epoch, 0)
+}
+
+// optionalEpochTime converts a Unix timestamp the API may report as null or omit, or the zero
+// time if it is unset
+func optionalEpochTime(epoch *int64) time.Time {
+	if epoch == nil {
+		return time.Time{}
+	}
+	return epochTime(*epoch)
+}
+
+// age returns how long ago t was, or -1 if t is unset
+func age(t time.Time) time.Duration {
+	if t.IsZero() {
+		return -1
+	}
+	return time.Since(t)
+}
+
+// stale indicates whether t is unset or older than maxAge
+func stale(t time.Time, maxAge time.Duration) bool {
+	return t.IsZero() || time.Since(t) > maxAge
+}
+
+// Time returns when the charge state was recorded by the vehicle
+func (s ChargeState) Time() time.Time {
+	return epochTime(s.Timestamp)
+}
+
+// Age returns how long ago the charge state was recorded, or -1 if its time is unknown
+func (s ChargeState) Age() time.Duration {
+	return age(s.Time())
+}
+
+// Stale indicates whether the charge state is older than maxAge, or its time is unknown
+func (s ChargeState) Stale(maxAge time.Duration) bool {
+	return stale(s.Time(), maxAge)
+}
+
+// ScheduledDepartureAt returns the time of the next scheduled departure, or the zero time if none is scheduled
+func (s ChargeState) ScheduledDepartureAt() time.Time {
+	return epochTime(s.ScheduledDepartureTime)
+}
+
+// BackseatTokenUpdated returns when the backseat token was last updated, or the zero time if it isn't set
+func (v Vehicle) BackseatTokenUpdated() time.Time {
+	return optionalEpochTime(v.BackseatTokenUpdatedAt)
+}
+
+// ManagedChargingStartAt returns when managed charging will start, or the zero time if it isn't set
+func (s ChargeState) ManagedChargingStartAt() time.Time {
+	return optionalEpochTime(s.ManagedChargingStartTime)
+}
+
+// Time returns when the climate state was recorded by the vehicle
+func (s ClimateState) Time() time.Time {
+	return epochTime(s.Timestamp)
+}
+
+// Age returns how long ago the climate state was recorded, or -1 if its time is unknown
+func (s ClimateState) Age() time.Duration {
+	return age(s.Time())
+}
+
+// Stale indicates whether the climate state is older than maxAge, or its time is unknown
+func (s ClimateState) Stale(maxAge time.Duration) bool {
+	return stale(s.Time(), maxAge)
+}
+
+// Time returns when the drive state was recorded by the vehicle
+func (s DriveState) Time() time.Time {
+	return epochTime(s.Timestamp)
+}
+
+// Age returns how long ago the drive state was recorded, or -1 if its time is unknown
+func (s DriveState) Age() time.Duration {
+	return age(s.Time())
+}
+
+// Stale indicates whether the drive state is older than maxAge, or its time is unknown
+func (s DriveState) Stale(maxAge time.Duration) bool {
+	return stale(s.Time(), maxAge)
+}
+
+// GpsTime returns when the vehicle's location was last fixed
+func (s DriveState) GpsTime() time.Time {
+	return epochTime(int64(s.GpsAsOf))
+}
+
+// GpsAge returns how long ago the vehicle's location was fixed, or -1 if its time is unknown
+func (s DriveState) GpsAge() time.Duration {
+	return age(s.GpsTime())
+}
+
+// GpsStale indicates whether the vehicle's location is older than maxAge, or its time is unknown
+func (s DriveState) GpsStale(maxAge time.Duration) bool {
+	return stale(s.GpsTime(), maxAge)
+}
+
+// Time returns when the GUI settings were recorded by the vehicle
+func (s GuiSettings) Time() time.Time {
+	return epochTime(s.Timestamp)
+}
+
+// Age returns how long ago the GUI settings were recorded, or -1 if their time is unknown
+func (s GuiSettings) Age() time.Duration {
+	return age(s.Time())
+}
+
+// Stale indicates whether the GUI settings are older than maxAge, or their time is unknown
+func (s GuiSettings) Stale(maxAge time.Duration) bool {
+	return stale(s.Time(), maxAge)
+}
+
+// Time returns when the vehicle config was recorded by the vehicle
+func (c VehicleConfig) Time() time.Time {
+	return epochTime(int64(c.Timestamp))
+}
+
+// Age returns how long ago the vehicle config was recorded, or -1 if its time is unknown
+func (c VehicleConfig) Age() time.Duration {
+	return age(c.Time())
+}
+
+// Stale indicates whether the vehicle config is older than maxAge, or its time is unknown
+func (c VehicleConfig) Stale(maxAge time.Duration) bool {
+	return stale(c.Time(), maxAge)
+}
+
+// Time returns when the vehicle state was recorded by the vehicle
+func (s VehicleState) Time() time.Time {
+	return epochTime(s.Timestamp)
+}
+
+// Age returns how long ago the vehicle state was recorded, or -1 if its time is unknown
+func (s VehicleState) Age() time.Duration {
+	return age(s.Time())
+}
+
+// Stale indicates whether the vehicle state is older than maxAge, or its time is unknown
+func (s VehicleState) Stale(maxAge time.Duration) bool {
+	return stale(s.Time(), maxAge)
+}
+
+// FreshChargeState returns the charge state of the vehicle, or ErrStaleData along with it
+// if it is older than maxAge
+func (v Vehicle) FreshChargeState(maxAge time.Duration) (*ChargeState, error) {
+	chargeState, err := v.ChargeState()
+	if err != nil {
+		return nil, err
+	}
+	if chargeState.Stale(maxAge) {
+		return chargeState, ErrStaleData
+	}
+	return chargeState, nil
+}
+
+// FreshDriveState returns the drive state of the vehicle, or ErrStaleData along with it if its
+// location is older than maxAge
+func (v Vehicle) FreshDriveState(maxAge time.Duration) (*DriveState, error) {
+	driveState, err := v.DriveState()
+	if err != nil {
+		return nil, err
+	}
+	if driveState.GpsStale(maxAge) {
+		return driveState, ErrStaleData
+	}
+	return driveState, nil
+}
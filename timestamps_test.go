@@ -0,0 +1,56 @@
+package tesla
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTimestampsSpec(t *testing.T) {
+	serveAPI(t)
+
+	Convey("Should convert timestamps in seconds and milliseconds", t, func() {
+		So(epochTime(1452491619).Equal(time.Unix(1452491619, 0)), ShouldBeTrue)
+		So(epochTime(1543186971731).Equal(time.Unix(1543186971, 731000000)), ShouldBeTrue)
+		So(epochTime(0).IsZero(), ShouldBeTrue)
+	})
+
+	Convey("Should populate the timestamp of fetched states", t, func() {
+		vehicle := Vehicle{ID: 1234}
+		vehicleConfig, err := vehicle.VehicleConfig()
+		So(err, ShouldBeNil)
+		So(vehicleConfig.Time().Equal(time.Unix(1543186971, 731000000)), ShouldBeTrue)
+		So(vehicleConfig.Age(), ShouldBeGreaterThan, 0)
+		So(vehicleConfig.Stale(time.Hour), ShouldBeTrue)
+	})
+
+	Convey("Should report the age of the vehicle's location", t, func() {
+		driveState, err := Vehicle{ID: 1234}.DriveState()
+		So(err, ShouldBeNil)
+		So(driveState.GpsTime().Unix(), ShouldEqual, 1452491619)
+		So(driveState.GpsStale(time.Hour), ShouldBeTrue)
+		So(driveState.Age(), ShouldEqual, -1)
+		So(driveState.Stale(time.Hour), ShouldBeTrue)
+
+		driveState, err = Vehicle{ID: 5678}.FreshDriveState(time.Minute)
+		So(err, ShouldBeNil)
+		So(driveState.GpsAge(), ShouldBeLessThan, time.Minute)
+		_, err = Vehicle{ID: 1234}.FreshDriveState(time.Minute)
+		So(err, ShouldEqual, ErrStaleData)
+	})
+
+	Convey("Should report charge data older than a threshold", t, func() {
+		chargeState := ChargeState{Timestamp: time.Now().Add(-10*time.Minute).UnixNano() / int64(time.Millisecond)}
+		So(chargeState.Stale(time.Minute), ShouldBeTrue)
+		So(chargeState.Stale(time.Hour), ShouldBeFalse)
+		So(ChargeState{}.Stale(time.Hour), ShouldBeTrue)
+		_, err := Vehicle{ID: 1234}.FreshChargeState(time.Minute)
+		So(err, ShouldEqual, ErrStaleData)
+	})
+
+	Convey("Should convert the scheduled departure time", t, func() {
+		So(ChargeState{ScheduledDepartureTime: 1618743600}.ScheduledDepartureAt().Unix(), ShouldEqual, 1618743600)
+		So(ChargeState{}.ScheduledDepartureAt().IsZero(), ShouldBeTrue)
+	})
+}
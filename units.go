@@ -0,0 +1,89 @@
+package tesla
+
+import "github.com/rdbell/tesla/units"
+
+// Units returns the units the vehicle's display is set to
+func (g GuiSettings) Units() units.Preferences {
+	return units.Preferences{
+		Distance:    units.ParseDistanceUnit(g.GuiDistanceUnits),
+		Temperature: units.ParseTemperatureUnit(g.GuiTemperatureUnits),
+	}
+}
+
+// Range returns the rated battery range
+func (s ChargeState) Range() units.Distance {
+	return units.FromMiles(s.BatteryRange)
+}
+
+// EstRange returns the estimated battery range, based on recent driving
+func (s ChargeState) EstRange() units.Distance {
+	return units.FromMiles(s.EstBatteryRange)
+}
+
+// IdealRange returns the ideal battery range
+func (s ChargeState) IdealRange() units.Distance {
+	return units.FromMiles(s.IdealBatteryRange)
+}
+
+// RangeAdded returns the rated range added during the current charge session
+func (s ChargeState) RangeAdded() units.Distance {
+	return units.FromMiles(s.ChargeMilesAddedRated)
+}
+
+// RangeChargeRate returns the rate range is being added while charging, as a speed
+func (s ChargeState) RangeChargeRate() units.Speed {
+	return units.FromMilesPerHour(s.ChargeRate)
+}
+
+// ChargingPower returns the power the charger is delivering
+func (s ChargeState) ChargingPower() units.Power {
+	return units.FromKilowatts(float64(s.ChargerPower))
+}
+
+// InsideTemperature returns the temperature inside the cabin
+func (s ClimateState) InsideTemperature() units.Temperature {
+	return units.FromCelsius(s.InsideTemp)
+}
+
+// OutsideTemperature returns the temperature outside the vehicle
+func (s ClimateState) OutsideTemperature() units.Temperature {
+	return units.FromCelsius(s.OutsideTemp)
+}
+
+// DriverTemperature returns the driver's temperature setting
+func (s ClimateState) DriverTemperature() units.Temperature {
+	return units.FromCelsius(s.DriverTempSetting)
+}
+
+// PassengerTemperature returns the passenger's temperature setting
+func (s ClimateState) PassengerTemperature() units.Temperature {
+	return units.FromCelsius(s.PassengerTempSetting)
+}
+
+// TemperatureRange returns the lowest and highest temperatures the climate can be set to
+func (s ClimateState) TemperatureRange() (min, max units.Temperature) {
+	return units.FromCelsius(s.MinAvailTemp), units.FromCelsius(s.MaxAvailTemp)
+}
+
+// CurrentSpeed returns the vehicle's speed, and false if it isn't reported because the vehicle is parked
+func (s DriveState) CurrentSpeed() (units.Speed, bool) {
+	if s.Speed == nil {
+		return 0, false
+	}
+	return units.FromMilesPerHour(*s.Speed), true
+}
+
+// PowerUsage returns the power the vehicle is drawing, which is negative while regenerating or charging
+func (s DriveState) PowerUsage() units.Power {
+	return units.FromKilowatts(float64(s.Power))
+}
+
+// OdometerDistance returns the distance the vehicle has travelled
+func (s VehicleState) OdometerDistance() units.Distance {
+	return units.FromMiles(s.Odometer)
+}
+
+// SpeedLimit returns the speed limit mode's current limit
+func (s VehicleState) SpeedLimit() units.Speed {
+	return units.FromMilesPerHour(s.SpeedLimitMode.CurrentLimitMph)
+}
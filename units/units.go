@@ -0,0 +1,205 @@
+// Package units provides typed distances, speeds, temperatures and powers reported by Tesla
+// vehicles, with conversions between metric and imperial units and the units a vehicle's
+// display is set to
+package units
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	metersPerMile      = 1609.344
+	metersPerKilometer = 1000
+	wattsPerHorsepower = 745.69987158227022
+)
+
+// DistanceUnit is a unit of distance, and of speed per hour
+type DistanceUnit string
+
+// Distance units
+const (
+	Miles      DistanceUnit = "mi"
+	Kilometers DistanceUnit = "km"
+)
+
+// TemperatureUnit is a unit of temperature
+type TemperatureUnit string
+
+// Temperature units
+const (
+	Celsius    TemperatureUnit = "C"
+	Fahrenheit TemperatureUnit = "F"
+)
+
+// Preferences are the units to present values in
+type Preferences struct {
+	Distance    DistanceUnit
+	Temperature TemperatureUnit
+}
+
+var (
+	// Metric presents values in kilometers and Celsius
+	Metric = Preferences{Distance: Kilometers, Temperature: Celsius}
+	// Imperial presents values in miles and Fahrenheit
+	Imperial = Preferences{Distance: Miles, Temperature: Fahrenheit}
+)
+
+// ParseDistanceUnit parses a distance unit as reported in the vehicle's GUI settings, such as
+// "mi/hr" or "km/hr". Anything other than kilometers is miles, as the API reports
+func ParseDistanceUnit(s string) DistanceUnit {
+	if strings.HasPrefix(strings.ToLower(s), "km") {
+		return Kilometers
+	}
+	return Miles
+}
+
+// ParseTemperatureUnit parses a temperature unit as reported in the vehicle's GUI settings,
+// "F" or "C". Anything other than Fahrenheit is Celsius, as the API reports
+func ParseTemperatureUnit(s string) TemperatureUnit {
+	if strings.HasPrefix(strings.ToUpper(s), "F") {
+		return Fahrenheit
+	}
+	return Celsius
+}
+
+// Distance is a distance in meters
+type Distance float64
+
+// FromMiles returns a distance of the supplied miles
+func FromMiles(miles float64) Distance {
+	return Distance(miles * metersPerMile)
+}
+
+// FromKilometers returns a distance of the supplied kilometers
+func FromKilometers(kilometers float64) Distance {
+	return Distance(kilometers * metersPerKilometer)
+}
+
+// Miles returns the distance in miles
+func (d Distance) Miles() float64 {
+	return float64(d) / metersPerMile
+}
+
+// Kilometers returns the distance in kilometers
+func (d Distance) Kilometers() float64 {
+	return float64(d) / metersPerKilometer
+}
+
+// In returns the distance in the supplied unit
+func (d Distance) In(unit DistanceUnit) float64 {
+	if unit == Kilometers {
+		return d.Kilometers()
+	}
+	return d.Miles()
+}
+
+// Format formats the distance in the supplied unit to one decimal place, e.g. "215.3 mi"
+func (d Distance) Format(unit DistanceUnit) string {
+	return format(d.In(unit), string(unit))
+}
+
+// Speed is a speed in meters per second
+type Speed float64
+
+// FromMilesPerHour returns a speed of the supplied miles per hour
+func FromMilesPerHour(mph float64) Speed {
+	return Speed(mph * metersPerMile / 3600)
+}
+
+// FromKilometersPerHour returns a speed of the supplied kilometers per hour
+func FromKilometersPerHour(kph float64) Speed {
+	return Speed(kph * metersPerKilometer / 3600)
+}
+
+// MilesPerHour returns the speed in miles per hour
+func (s Speed) MilesPerHour() float64 {
+	return float64(s) * 3600 / metersPerMile
+}
+
+// KilometersPerHour returns the speed in kilometers per hour
+func (s Speed) KilometersPerHour() float64 {
+	return float64(s) * 3600 / metersPerKilometer
+}
+
+// In returns the speed in the supplied unit per hour
+func (s Speed) In(unit DistanceUnit) float64 {
+	if unit == Kilometers {
+		return s.KilometersPerHour()
+	}
+	return s.MilesPerHour()
+}
+
+// Format formats the speed in the supplied unit per hour to one decimal place, e.g. "65.0 mi/hr"
+func (s Speed) Format(unit DistanceUnit) string {
+	return format(s.In(unit), string(unit)+"/hr")
+}
+
+// Temperature is a temperature in degrees Celsius
+type Temperature float64
+
+// FromCelsius returns a temperature of the supplied degrees Celsius
+func FromCelsius(celsius float64) Temperature {
+	return Temperature(celsius)
+}
+
+// FromFahrenheit returns a temperature of the supplied degrees Fahrenheit
+func FromFahrenheit(fahrenheit float64) Temperature {
+	return Temperature((fahrenheit - 32) * 5 / 9)
+}
+
+// Celsius returns the temperature in degrees Celsius
+func (t Temperature) Celsius() float64 {
+	return float64(t)
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit
+func (t Temperature) Fahrenheit() float64 {
+	return float64(t)*9/5 + 32
+}
+
+// In returns the temperature in the supplied unit
+func (t Temperature) In(unit TemperatureUnit) float64 {
+	if unit == Fahrenheit {
+		return t.Fahrenheit()
+	}
+	return t.Celsius()
+}
+
+// Format formats the temperature in the supplied unit to one decimal place, e.g. "21.5°C"
+func (t Temperature) Format(unit TemperatureUnit) string {
+	return strconv.FormatFloat(t.In(unit), 'f', 1, 64) + "°" + string(unit)
+}
+
+// Power is a power in watts
+type Power float64
+
+// FromKilowatts returns a power of the supplied kilowatts
+func FromKilowatts(kilowatts float64) Power {
+	return Power(kilowatts * 1000)
+}
+
+// Watts returns the power in watts
+func (p Power) Watts() float64 {
+	return float64(p)
+}
+
+// Kilowatts returns the power in kilowatts
+func (p Power) Kilowatts() float64 {
+	return float64(p) / 1000
+}
+
+// Horsepower returns the power in mechanical horsepower
+func (p Power) Horsepower() float64 {
+	return float64(p) / wattsPerHorsepower
+}
+
+// String formats the power in kilowatts to one decimal place, e.g. "11.0 kW"
+func (p Power) String() string {
+	return format(p.Kilowatts(), "kW")
+}
+
+// format formats a value to one decimal place followed by its unit
+func format(value float64, unit string) string {
+	return strconv.FormatFloat(value, 'f', 1, 64) + " " + unit
+}
@@ -0,0 +1,51 @@
+package units
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitsSpec(t *testing.T) {
+	Convey("Should convert distances", t, func() {
+		d := FromMiles(100)
+		So(d.Kilometers(), ShouldAlmostEqual, 160.9344, 0.0001)
+		So(d.In(Miles), ShouldAlmostEqual, 100, 0.0001)
+		So(FromKilometers(160.9344).Miles(), ShouldAlmostEqual, 100, 0.0001)
+		So(d.Format(Kilometers), ShouldEqual, "160.9 km")
+		So(d.Format(Miles), ShouldEqual, "100.0 mi")
+	})
+
+	Convey("Should convert speeds", t, func() {
+		s := FromMilesPerHour(65)
+		So(s.KilometersPerHour(), ShouldAlmostEqual, 104.607, 0.001)
+		So(FromKilometersPerHour(100).MilesPerHour(), ShouldAlmostEqual, 62.137, 0.001)
+		So(s.Format(Miles), ShouldEqual, "65.0 mi/hr")
+		So(s.Format(Kilometers), ShouldEqual, "104.6 km/hr")
+	})
+
+	Convey("Should convert temperatures", t, func() {
+		So(FromCelsius(100).Fahrenheit(), ShouldAlmostEqual, 212, 0.0001)
+		So(FromFahrenheit(32).Celsius(), ShouldAlmostEqual, 0, 0.0001)
+		So(FromCelsius(21.5).In(Fahrenheit), ShouldAlmostEqual, 70.7, 0.0001)
+		So(FromCelsius(21.5).Format(Celsius), ShouldEqual, "21.5°C")
+		So(FromCelsius(22).Format(Fahrenheit), ShouldEqual, "71.6°F")
+	})
+
+	Convey("Should convert powers", t, func() {
+		p := FromKilowatts(11)
+		So(p.Watts(), ShouldEqual, 11000)
+		So(p.Kilowatts(), ShouldEqual, 11)
+		So(FromKilowatts(745.69987158227022/1000).Horsepower(), ShouldAlmostEqual, 1, 0.0001)
+		So(p.String(), ShouldEqual, "11.0 kW")
+	})
+
+	Convey("Should parse the units reported in GUI settings", t, func() {
+		So(ParseDistanceUnit("mi/hr"), ShouldEqual, Miles)
+		So(ParseDistanceUnit("km/hr"), ShouldEqual, Kilometers)
+		So(ParseTemperatureUnit("F"), ShouldEqual, Fahrenheit)
+		So(ParseTemperatureUnit("C"), ShouldEqual, Celsius)
+		So(Metric.Distance, ShouldEqual, Kilometers)
+		So(Imperial.Temperature, ShouldEqual, Fahrenheit)
+	})
+}
@@ -0,0 +1,52 @@
+package tesla
+
+import (
+	"testing"
+
+	"github.com/rdbell/tesla/units"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitsSpec(t *testing.T) {
+	serveAPI(t)
+
+	vehicle := Vehicle{ID: 1234}
+
+	Convey("Should present values in the vehicle's display units", t, func() {
+		guiSettings, err := vehicle.GuiSettings()
+		So(err, ShouldBeNil)
+		prefs := guiSettings.Units()
+		So(prefs, ShouldResemble, units.Imperial)
+
+		chargeState, err := vehicle.ChargeState()
+		So(err, ShouldBeNil)
+		So(chargeState.Range().In(prefs.Distance), ShouldAlmostEqual, 235.92, 0.0001)
+		So(chargeState.Range().Format(units.Metric.Distance), ShouldEqual, "379.7 km")
+
+		climateState, err := vehicle.ClimateState()
+		So(err, ShouldBeNil)
+		So(climateState.DriverTemperature().Format(prefs.Temperature), ShouldEqual, "71.6°F")
+
+		vehicleState, err := vehicle.VehicleState()
+		So(err, ShouldBeNil)
+		So(vehicleState.OdometerDistance().Kilometers(), ShouldAlmostEqual, 6017.090, 0.001)
+	})
+
+	Convey("Should report the speed only while driving", t, func() {
+		driveState, err := vehicle.DriveState()
+		So(err, ShouldBeNil)
+		_, ok := driveState.CurrentSpeed()
+		So(ok, ShouldBeFalse)
+		speed := 65.0
+		speedMph, ok := DriveState{Speed: &speed}.CurrentSpeed()
+		So(ok, ShouldBeTrue)
+		So(speedMph.KilometersPerHour(), ShouldAlmostEqual, 104.607, 0.001)
+	})
+
+	Convey("Should convert charging values", t, func() {
+		chargeState := ChargeState{ChargeRate: 30, ChargerPower: 11, ChargeMilesAddedRated: 50}
+		So(chargeState.RangeChargeRate().Format(units.Kilometers), ShouldEqual, "48.3 km/hr")
+		So(chargeState.ChargingPower().String(), ShouldEqual, "11.0 kW")
+		So(chargeState.RangeAdded().Miles(), ShouldAlmostEqual, 50, 0.0001)
+	})
+}
@@ -0,0 +1,45 @@
+package tesla
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ErrValetPINRequired is returned when valet mode is enabled without a PIN, but the vehicle needs one
+var ErrValetPINRequired = errors.New("vehicle needs a valet PIN")
+
+// EnableValetMode turns on valet mode, protected by the supplied four digit PIN. The PIN may be
+// empty if the vehicle already has one saved, which is checked by fetching the vehicle state
+func (v Vehicle) EnableValetMode(pin string) error {
+	if pin == "" {
+		vehicleState, err := v.VehicleState()
+		if err != nil {
+			return err
+		}
+		if vehicleState.ValetPinNeeded {
+			return ErrValetPINRequired
+		}
+		return v.setValetMode(true, pin)
+	}
+	if !validPIN(pin) {
+		return ErrInvalidPIN
+	}
+	return v.setValetMode(true, pin)
+}
+
+// DisableValetMode turns off valet mode. The PIN may be empty if the vehicle reports it isn't needed
+func (v Vehicle) DisableValetMode(pin string) error {
+	if pin != "" && !validPIN(pin) {
+		return ErrInvalidPIN
+	}
+	return v.setValetMode(false, pin)
+}
+
+// setValetMode sends the valet mode command
+func (v Vehicle) setValetMode(on bool, pin string) error {
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/set_valet_mode"
+	body, _ := json.Marshal(valetPayload{on, pin})
+	_, err := v.sendCommand(apiURL, body)
+	return err
+}
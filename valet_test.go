@@ -0,0 +1,39 @@
+package tesla
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	ValetPinNeededVehicleStateJSON = `{"response":{"valet_mode":false,"valet_pin_needed":true,"vehicle_name":"Macak"}}`
+)
+
+func TestValetSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should enable valet mode with a PIN", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.EnableValetMode("1234"), ShouldBeNil)
+		So(vehicle.EnableValetMode("12"), ShouldEqual, ErrInvalidPIN)
+	})
+
+	Convey("Should enable valet mode without a PIN when the vehicle doesn't need one", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.EnableValetMode(""), ShouldBeNil)
+		So(Vehicle{ID: 4680}.EnableValetMode(""), ShouldEqual, ErrValetPINRequired)
+	})
+
+	Convey("Should disable valet mode", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.DisableValetMode(""), ShouldBeNil)
+		So(vehicle.DisableValetMode("12345"), ShouldEqual, ErrInvalidPIN)
+	})
+}
@@ -1,23 +1,32 @@
 package tesla
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
 
 // Represents the vehicle as returned from the Tesla API
 type Vehicle struct {
-	Color                  interface{} `json:"color"`
-	DisplayName            string      `json:"display_name"`
-	ID                     int64       `json:"id"`
-	OptionCodes            string      `json:"option_codes"`
-	VehicleID              int         `json:"vehicle_id"`
-	Vin                    string      `json:"vin"`
-	Tokens                 []string    `json:"tokens"`
-	State                  string      `json:"state"`
-	IDS                    string      `json:"id_s"`
-	RemoteStartEnabled     bool        `json:"remote_start_enabled"`
-	CalendarEnabled        bool        `json:"calendar_enabled"`
-	NotificationsEnabled   bool        `json:"notifications_enabled"`
-	BackseatToken          interface{} `json:"backseat_token"`
-	BackseatTokenUpdatedAt interface{} `json:"backseat_token_updated_at"`
+	Color                  *string  `json:"color"`
+	DisplayName            string   `json:"display_name"`
+	ID                     int64    `json:"id"`
+	OptionCodes            string   `json:"option_codes"`
+	VehicleID              int      `json:"vehicle_id"`
+	Vin                    string   `json:"vin"`
+	Tokens                 []string `json:"tokens"`
+	State                  string   `json:"state"`
+	IDS                    string   `json:"id_s"`
+	RemoteStartEnabled     bool     `json:"remote_start_enabled"`
+	CalendarEnabled        bool     `json:"calendar_enabled"`
+	NotificationsEnabled   bool     `json:"notifications_enabled"`
+	BackseatToken          *string  `json:"backseat_token"`
+	BackseatTokenUpdatedAt *int64   `json:"backseat_token_updated_at"`
+
+	client *Client
 }
 
 // The response that contains the vehicle details from the Tesla API
@@ -28,9 +37,10 @@ type VehicleResponse struct {
 
 // Represents the vehicles from an account, as you could have more than
 // one Tesla associated to your account
-type Vehicles []struct {
-	*Vehicle
-}
+type Vehicles []*Vehicle
+
+// ErrVehicleNotFound is returned when the vehicle isn't on the account
+var ErrVehicleNotFound = errors.New("vehicle not found")
 
 // The response that contains the vehicles details from the Tesla API
 type VehiclesResponse struct {
@@ -40,8 +50,13 @@ type VehiclesResponse struct {
 
 // Fetches the vehicles associated to a Tesla account via the API
 func (c *Client) Vehicles() (Vehicles, error) {
+	return c.vehicles(context.Background())
+}
+
+// vehicles fetches the vehicles associated to the account, as part of the supplied context
+func (c *Client) vehicles(ctx context.Context) (Vehicles, error) {
 	vehiclesResponse := &VehiclesResponse{}
-	body, err := c.get(BaseURL + "/vehicles")
+	body, err := c.getContext(ctx, BaseURL+"/vehicles")
 	if err != nil {
 		return nil, err
 	}
@@ -49,5 +64,77 @@ func (c *Client) Vehicles() (Vehicles, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, vehicle := range vehiclesResponse.Response {
+		vehicle.client = c
+	}
 	return vehiclesResponse.Response, nil
 }
+
+// apiClient returns the client the vehicle was fetched with, or the active client
+func (v Vehicle) apiClient() *Client {
+	if v.client != nil {
+		return v.client
+	}
+	return ActiveClient
+}
+
+// Vehicle fetches the vehicle with the supplied ID via the API. This doesn't wake the vehicle
+func (c *Client) Vehicle(id int64) (*Vehicle, error) {
+	vehicleResponse := &VehicleResponse{}
+	body, err := c.get(BaseURL + "/vehicles/" + strconv.FormatInt(id, 10))
+	if err != nil {
+		// The API responds with a 404 for vehicles that aren't on the account
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, ErrVehicleNotFound
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(body, vehicleResponse)
+	if err != nil {
+		return nil, err
+	}
+	if vehicleResponse.Response == nil {
+		return nil, ErrVehicleNotFound
+	}
+	vehicleResponse.Response.client = c
+	return vehicleResponse.Response, nil
+}
+
+// VehicleByVIN returns the vehicle on the account with the supplied VIN
+func (c *Client) VehicleByVIN(vin string) (*Vehicle, error) {
+	return c.findVehicle(func(v *Vehicle) bool {
+		return v.Vin == vin
+	})
+}
+
+// VehicleByName returns the vehicle on the account with the supplied display name, ignoring case
+func (c *Client) VehicleByName(name string) (*Vehicle, error) {
+	return c.findVehicle(func(v *Vehicle) bool {
+		return strings.EqualFold(v.DisplayName, name)
+	})
+}
+
+// Returns the first vehicle on the account that matches
+func (c *Client) findVehicle(match func(v *Vehicle) bool) (*Vehicle, error) {
+	vehicles, err := c.Vehicles()
+	if err != nil {
+		return nil, err
+	}
+	for _, vehicle := range vehicles {
+		if match(vehicle) {
+			return vehicle, nil
+		}
+	}
+	return nil, ErrVehicleNotFound
+}
+
+// Refresh reloads the vehicle's summary, such as its state and display name, via the API
+func (v *Vehicle) Refresh() error {
+	vehicle, err := v.apiClient().Vehicle(v.ID)
+	if err != nil {
+		return err
+	}
+	*v = *vehicle
+	return nil
+}
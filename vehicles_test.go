@@ -1,6 +1,8 @@
 package tesla
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -8,6 +10,7 @@ import (
 
 var (
 	VehiclesJSON = `{"response":[{"color":null,"display_name":"Macak","id":1234,"option_codes":"MS04,RENA,AU01,BC0R,BP01,BR01,BS00,CDM0,CH00,PBSB,CW02,DA02,DCF0,DRLH,DSH7,DV4W,FG02,HP00,IDPB,IX01,LP01,ME02,MI00,PA00,PF01,PI01,PK00,PS01,PX00,PX4D,QNEB,RFP2,SC01,SP00,SR01,SU01,TM00,TP03,TR01,UTAB,WTSG,WTX0,X001,X003,X007,X011,X013,X019,X024,X027,X028,X031,X037,X040,YF01,COUS","vehicle_id":456,"vin":"abc123","tokens":["1","2"],"state":"online","id_s":"789","remote_start_enabled":true,"calendar_enabled":true,"notifications_enabled":true,"backseat_token":null,"backseat_token_updated_at":null}],"count":1}`
+	VehicleJSON  = `{"response":{"color":null,"display_name":"Macak","id":1234,"option_codes":"MS04,RENA,AU01,BC0R,BP01,BR01,BS00,CDM0,CH00,PBSB,CW02,DA02,DCF0,DRLH,DSH7,DV4W,FG02,HP00,IDPB,IX01,LP01,ME02,MI00,PA00,PF01,PI01,PK00,PS01,PX00,PX4D,QNEB,RFP2,SC01,SP00,SR01,SU01,TM00,TP03,TR01,UTAB,WTSG,WTX0,X001,X003,X007,X011,X013,X019,X024,X027,X028,X031,X037,X040,YF01,COUS","vehicle_id":456,"vin":"abc123","tokens":["1","2"],"state":"asleep","id_s":"789","remote_start_enabled":true,"calendar_enabled":true,"notifications_enabled":true,"backseat_token":null,"backseat_token_updated_at":null},"count":1}`
 )
 
 func TestVehiclesSpec(t *testing.T) {
@@ -34,6 +37,74 @@ func TestVehiclesSpec(t *testing.T) {
 		So(vehicles[0].CalendarEnabled, ShouldBeTrue)
 	})
 
+	Convey("Should get a vehicle by ID", t, func() {
+		vehicle, err := client.Vehicle(1234)
+		So(err, ShouldBeNil)
+		So(vehicle.DisplayName, ShouldEqual, "Macak")
+		So(vehicle.State, ShouldEqual, "asleep")
+	})
+
+	Convey("Should return ErrVehicleNotFound for an unknown ID", t, func() {
+		vehicle, err := client.Vehicle(9999)
+		So(err, ShouldEqual, ErrVehicleNotFound)
+		So(vehicle, ShouldBeNil)
+		vehicle, err = client.Vehicle(8642)
+		So(err, ShouldEqual, ErrVehicleNotFound)
+		So(vehicle, ShouldBeNil)
+	})
+
+	Convey("Should find a vehicle by VIN", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle, err := client.VehicleByVIN(vehicles[0].Vin)
+		So(err, ShouldBeNil)
+		So(vehicle.ID, ShouldEqual, 1234)
+		_, err = client.VehicleByVIN("5YJSA1H10EFP00000")
+		So(err, ShouldEqual, ErrVehicleNotFound)
+	})
+
+	Convey("Should find a vehicle by name", t, func() {
+		vehicle, err := client.VehicleByName("macak")
+		So(err, ShouldBeNil)
+		So(vehicle.ID, ShouldEqual, 1234)
+		_, err = client.VehicleByName("Nikola")
+		So(err, ShouldEqual, ErrVehicleNotFound)
+	})
+
+	Convey("Should refresh a vehicle's summary", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		So(vehicle.State, ShouldEqual, "online")
+		err = vehicle.Refresh()
+		So(err, ShouldBeNil)
+		So(vehicle.State, ShouldEqual, "asleep")
+		So(vehicle.ID, ShouldEqual, 1234)
+
+		err = (&Vehicle{ID: 9999}).Refresh()
+		So(err, ShouldEqual, ErrVehicleNotFound)
+	})
+
+	Convey("Should refresh with the client that fetched the vehicle", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		previousClient := ActiveClient
+		ActiveClient = nil
+		defer func() { ActiveClient = previousClient }()
+		So(vehicle.Refresh(), ShouldBeNil)
+		So(vehicle.State, ShouldEqual, "asleep")
+		So(vehicle.Refresh(), ShouldBeNil)
+	})
+
+	Convey("Should report unexpected statuses as a StatusError", t, func() {
+		_, err := (&Vehicle{ID: 9999}).ChargeState()
+		var statusErr *StatusError
+		So(errors.As(fmt.Errorf("fetching charge state: %w", err), &statusErr), ShouldBeTrue)
+		So(statusErr.StatusCode, ShouldEqual, 404)
+		So(err.Error(), ShouldEqual, "404 Not Found")
+	})
+
 	AuthURL = previousAuthURL
 	BaseURL = previousURL
 }
@@ -0,0 +1,122 @@
+package tesla
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// VerifyPollInterval is how often a command's target state is checked while verifying
+var VerifyPollInterval = 2 * time.Second
+
+// ErrStateNotConverged is returned when a verified command's target state isn't reached before the timeout
+var ErrStateNotConverged = errors.New("vehicle did not reach the expected state before the timeout")
+
+// Verification describes whether, and when, the vehicle reached the target state of a command
+type Verification struct {
+	Converged   bool
+	Attempts    int
+	Elapsed     time.Duration
+	ConvergedAt time.Time
+}
+
+// VerifyCommand sends a command and then polls converged until it reports the vehicle has reached
+// the command's target state. ErrStateNotConverged is returned along with the verification if the
+// state hasn't converged once the timeout has passed, or the context's error if it is done first
+func VerifyCommand(ctx context.Context, command func() error, converged func() (bool, error), timeout time.Duration) (*Verification, error) {
+	err := command()
+	if err != nil {
+		return nil, err
+	}
+	verification := &Verification{}
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for {
+		verification.Attempts++
+		ok, err := converged()
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		verification.Elapsed = now.Sub(start)
+		if ok {
+			verification.Converged = true
+			verification.ConvergedAt = now
+			return verification, nil
+		}
+		if now.Add(VerifyPollInterval).After(deadline) {
+			return verification, ErrStateNotConverged
+		}
+		select {
+		case <-time.After(VerifyPollInterval):
+		case <-ctx.Done():
+			return verification, ctx.Err()
+		}
+	}
+}
+
+// LockDoorsAndVerify locks the doors and waits for the vehicle to report it is locked
+func (v Vehicle) LockDoorsAndVerify(ctx context.Context, timeout time.Duration) (*Verification, error) {
+	return VerifyCommand(ctx, func() error {
+		return v.verifiedCommand(ctx, "door_lock", nil)
+	}, func() (bool, error) {
+		vehicleState := &VehicleState{}
+		err := v.fetchStateContext(ctx, "/vehicle_state", vehicleState)
+		if err != nil {
+			return false, err
+		}
+		return vehicleState.Locked, nil
+	}, timeout)
+}
+
+// StartChargingAndVerify starts charging and waits for the vehicle to report it is charging
+func (v Vehicle) StartChargingAndVerify(ctx context.Context, timeout time.Duration) (*Verification, error) {
+	return VerifyCommand(ctx, func() error {
+		return v.verifiedCommand(ctx, "charge_start", nil)
+	}, func() (bool, error) {
+		chargeState := &ChargeState{}
+		err := v.fetchStateContext(ctx, "/charge_state", chargeState)
+		if err != nil {
+			return false, err
+		}
+		return chargeState.ChargingState == ChargingCharging, nil
+	}, timeout)
+}
+
+// SetSentryModeAndVerify sets Sentry Mode and waits for the vehicle to report the new state
+func (v Vehicle) SetSentryModeAndVerify(ctx context.Context, on bool, timeout time.Duration) (*Verification, error) {
+	return VerifyCommand(ctx, func() error {
+		body, _ := json.Marshal(switchPayload{strconv.FormatBool(on)})
+		return v.verifiedCommand(ctx, "set_sentry_mode", body)
+	}, func() (bool, error) {
+		vehicleState := &VehicleState{}
+		err := v.fetchStateContext(ctx, "/vehicle_state", vehicleState)
+		if err != nil {
+			return false, err
+		}
+		return vehicleState.SentryMode == on, nil
+	}, timeout)
+}
+
+// OpenChargePortAndVerify opens the charge port and waits for the vehicle to report it is open
+func (v Vehicle) OpenChargePortAndVerify(ctx context.Context, timeout time.Duration) (*Verification, error) {
+	return VerifyCommand(ctx, func() error {
+		return v.verifiedCommand(ctx, "charge_port_door_open", nil)
+	}, func() (bool, error) {
+		chargeState := &ChargeState{}
+		err := v.fetchStateContext(ctx, "/charge_state", chargeState)
+		if err != nil {
+			return false, err
+		}
+		return chargeState.ChargePortDoorOpen, nil
+	}, timeout)
+}
+
+// verifiedCommand sends the command being verified as part of the verification's context
+func (v Vehicle) verifiedCommand(ctx context.Context, command string, body []byte) error {
+	apiURL := BaseURL + "/vehicles/" + strconv.FormatInt(v.ID, 10) + "/command/" + command
+	_, err := v.sendCommandContext(ctx, apiURL, body)
+	return err
+}
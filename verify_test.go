@@ -0,0 +1,123 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVerifySpec(t *testing.T) {
+	_, client := serveAPI(t)
+	previousPollInterval := VerifyPollInterval
+	VerifyPollInterval = 10 * time.Millisecond
+
+	Convey("Should verify a command once the state converges", t, func() {
+		polls := 0
+		verification, err := VerifyCommand(context.Background(), func() error {
+			return nil
+		}, func() (bool, error) {
+			polls++
+			return polls == 3, nil
+		}, time.Second)
+		So(err, ShouldBeNil)
+		So(verification.Converged, ShouldBeTrue)
+		So(verification.Attempts, ShouldEqual, 3)
+		So(verification.ConvergedAt.IsZero(), ShouldBeFalse)
+	})
+
+	Convey("Should not poll when the command fails", t, func() {
+		commandErr := errors.New("vehicle unavailable")
+		verification, err := VerifyCommand(context.Background(), func() error {
+			return commandErr
+		}, func() (bool, error) {
+			panic("should not poll")
+		}, time.Second)
+		So(err, ShouldEqual, commandErr)
+		So(verification, ShouldBeNil)
+	})
+
+	Convey("Should stop polling once the context is canceled", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		verification, err := VerifyCommand(ctx, func() error {
+			return nil
+		}, func() (bool, error) {
+			cancel()
+			return false, nil
+		}, time.Minute)
+		So(err, ShouldEqual, context.Canceled)
+		So(verification.Attempts, ShouldEqual, 1)
+	})
+
+	Convey("Should lock the doors and verify they locked", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		verification, err := vehicle.LockDoorsAndVerify(context.Background(), time.Second)
+		So(err, ShouldBeNil)
+		So(verification.Converged, ShouldBeTrue)
+	})
+
+	Convey("Should set Sentry Mode and verify it", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		verification, err := vehicle.SetSentryModeAndVerify(context.Background(), false, time.Second)
+		So(err, ShouldBeNil)
+		So(verification.Converged, ShouldBeTrue)
+	})
+
+	Convey("Should report a charge port that never opens", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		verification, err := vehicle.OpenChargePortAndVerify(context.Background(), 50*time.Millisecond)
+		So(err, ShouldEqual, ErrStateNotConverged)
+		So(verification.Converged, ShouldBeFalse)
+		So(verification.Attempts, ShouldBeGreaterThan, 1)
+	})
+
+	Convey("Should surface the reason charging couldn't start", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		_, err = vehicle.StartChargingAndVerify(context.Background(), time.Second)
+		So(err.Error(), ShouldEqual, "complete")
+	})
+
+	Convey("Should cancel in-flight command and state requests with the context", t, func() {
+		_, blocking := serveAPI(t)
+		blocked := "/command/"
+		blocking.Use(func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, blocked) {
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(time.Second):
+				}
+			}
+			return next(req)
+		})
+		vehicles, err := blocking.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = vehicle.LockDoorsAndVerify(ctx, time.Minute)
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		blocked = "/data_request/"
+		ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = vehicle.SetSentryModeAndVerify(ctx, false, time.Minute)
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+	})
+
+	VerifyPollInterval = previousPollInterval
+}
@@ -0,0 +1,81 @@
+package tesla
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WindowPosition is the reported position of a single window
+type WindowPosition int
+
+// Window positions as reported by the vehicle state
+const (
+	WindowClosed WindowPosition = iota
+	WindowVented
+	WindowOpen
+)
+
+// ErrWindowsNotClosed is returned when the windows don't report closed before the timeout
+var ErrWindowsNotClosed = errors.New("windows did not close before the timeout")
+
+// WindowState is a typed view of the positions of the vehicle's windows
+type WindowState struct {
+	FrontDriver    WindowPosition
+	FrontPassenger WindowPosition
+	RearDriver     WindowPosition
+	RearPassenger  WindowPosition
+}
+
+// Closed indicates whether the window is fully closed
+func (p WindowPosition) Closed() bool {
+	return p == WindowClosed
+}
+
+// String returns the name of the window position
+func (p WindowPosition) String() string {
+	switch p {
+	case WindowClosed:
+		return "closed"
+	case WindowVented:
+		return "vented"
+	}
+	return "open"
+}
+
+// AllClosed indicates whether every window is fully closed
+func (w WindowState) AllClosed() bool {
+	return w.FrontDriver.Closed() && w.FrontPassenger.Closed() && w.RearDriver.Closed() && w.RearPassenger.Closed()
+}
+
+// Windows returns the positions of the vehicle's windows
+func (s VehicleState) Windows() WindowState {
+	return WindowState{
+		FrontDriver:    WindowPosition(s.FdWindow),
+		FrontPassenger: WindowPosition(s.FpWindow),
+		RearDriver:     WindowPosition(s.RdWindow),
+		RearPassenger:  WindowPosition(s.RpWindow),
+	}
+}
+
+// CloseWindowsAndVerify closes the windows, then polls the vehicle state until all of the
+// windows report closed. ErrWindowsNotClosed is returned along with the last known window
+// state if they are still open once the timeout has passed
+func (v Vehicle) CloseWindowsAndVerify(ctx context.Context, timeout time.Duration) (*WindowState, error) {
+	windows := &WindowState{}
+	_, err := VerifyCommand(ctx, v.CloseWindows, func() (bool, error) {
+		vehicleState, err := v.VehicleState()
+		if err != nil {
+			return false, err
+		}
+		*windows = vehicleState.Windows()
+		return windows.AllClosed(), nil
+	}, timeout)
+	if err == ErrStateNotConverged {
+		return windows, ErrWindowsNotClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,46 @@
+package tesla
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	OpenWindowsVehicleStateJSON = `{"response":{"fd_window":0,"fp_window":1,"rd_window":2,"rp_window":0}}`
+)
+
+func TestWindowsSpec(t *testing.T) {
+	_, client := serveAPI(t)
+
+	Convey("Should read the window positions", t, func() {
+		stateRequest := &StateRequest{}
+		err := json.Unmarshal([]byte(OpenWindowsVehicleStateJSON), stateRequest)
+		So(err, ShouldBeNil)
+		windows := stateRequest.Response.VehicleState.Windows()
+		So(windows.FrontDriver, ShouldEqual, WindowClosed)
+		So(windows.FrontPassenger, ShouldEqual, WindowVented)
+		So(windows.RearDriver.String(), ShouldEqual, "open")
+		So(windows.AllClosed(), ShouldBeFalse)
+	})
+
+	Convey("Should vent the windows", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		err = vehicle.VentWindows()
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Should close the windows and verify they closed", t, func() {
+		vehicles, err := client.Vehicles()
+		So(err, ShouldBeNil)
+		vehicle := vehicles[0]
+		windows, err := vehicle.CloseWindowsAndVerify(context.Background(), time.Second)
+		So(err, ShouldBeNil)
+		So(windows.AllClosed(), ShouldBeTrue)
+	})
+}